@@ -1,7 +1,11 @@
 package aws
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	ecrapi "github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/dtan4/ecrcli/aws/ecr"
@@ -11,31 +15,54 @@ import (
 var (
 	// ECR represents ECR API client
 	ECR *ecr.Client
+
+	// currentRegion and currentCredentials are those AWS API clients are initialized with
+	currentRegion      string
+	currentCredentials *credentials.Credentials
 )
 
 // Initialize creates AWS API clients
-func Initialize(region string) error {
-	var sess *session.Session
-
-	if region == "" {
-		s, err := session.NewSession()
-		if err != nil {
-			return errors.Wrap(err, "failed to create new AWS session")
-		}
-
-		sess = s
-	} else {
-		s, err := session.NewSession(&aws.Config{
-			Region: aws.String(region),
+// timeout is applied to each API request. 0 means no timeout
+func Initialize(region string, timeout time.Duration) error {
+	config := aws.NewConfig()
+
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+
+	if timeout > 0 {
+		config = config.WithHTTPClient(&http.Client{
+			Timeout: timeout,
 		})
-		if err != nil {
-			return errors.Wrap(err, "failed to create new AWS session")
-		}
+	}
 
-		sess = s
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create new AWS session")
 	}
 
 	ECR = ecr.NewClient(ecrapi.New(sess))
+	currentRegion = aws.StringValue(sess.Config.Region)
+	currentCredentials = sess.Config.Credentials
 
 	return nil
 }
+
+// AccessKeyID returns the access key ID of the credentials AWS API clients use
+func AccessKeyID() (string, error) {
+	if currentCredentials == nil {
+		return "", errors.New("AWS API clients are not initialized")
+	}
+
+	v, err := currentCredentials.Get()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+
+	return v.AccessKeyID, nil
+}
+
+// Region returns the region AWS API clients are initialized with
+func Region() string {
+	return currentRegion
+}
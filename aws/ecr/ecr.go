@@ -7,31 +7,89 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/pkg/errors"
 )
 
+const (
+	// batchDeleteImageLimit is the maximum number of image IDs in one BatchDeleteImage request
+	batchDeleteImageLimit = 100
+
+	digestPrefix = "sha256:"
+)
+
+var (
+	// manifestMediaTypes is the list of manifest media types accepted by GetManifest
+	// Without this, ECR converts manifests into Docker schema1
+	manifestMediaTypes = []string{
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.oci.image.index.v1+json",
+	}
+
+	// tagManifestMediaTypes is the list of manifest media types accepted by TagImage
+	// Schema1 types are also accepted so that the stored manifest is written back as it is
+	tagManifestMediaTypes = append([]string{
+		"application/vnd.docker.distribution.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v1+prettyjws",
+	}, manifestMediaTypes...)
+)
+
+var (
+	// ErrImageAlreadyExists is returned by TagImage if the tag already points to the source image
+	ErrImageAlreadyExists = errors.New("image already exists")
+)
+
 // Client represents the wrapper of ECR API client
 type Client struct {
 	api ecriface.ECRAPI
 }
 
+// Credentials represents the credentials to log in to ECR registry
+type Credentials struct {
+	Username      string    `json:"Username"`
+	Password      string    `json:"Password"`
+	ProxyEndpoint string    `json:"ProxyEndpoint"`
+	ExpiresAt     time.Time `json:"ExpiresAt"`
+}
+
 // Image represents the metadata of Docker image
 type Image struct {
-	Repository  string
-	Digest      string
-	Tags        []string
-	SizeInBytes int64
-	PushedAt    time.Time
+	Repository  string    `json:"Repository"`
+	Digest      string    `json:"Digest"`
+	Tags        []string  `json:"Tags"`
+	SizeInBytes int64     `json:"SizeInBytes"`
+	PushedAt    time.Time `json:"PushedAt"`
+}
+
+// ImageID represents the identifier of Docker image, either digest or tag
+type ImageID struct {
+	Digest string `json:"Digest,omitempty"`
+	Tag    string `json:"Tag,omitempty"`
+}
+
+// ImageFailure represents the failure of operation against Docker image
+type ImageFailure struct {
+	ImageID *ImageID `json:"ImageID"`
+	Code    string   `json:"Code"`
+	Reason  string   `json:"Reason"`
+}
+
+// DeleteImagesResult represents the result of image deletion
+type DeleteImagesResult struct {
+	Deleted  []*ImageID      `json:"Deleted"`
+	Failures []*ImageFailure `json:"Failures"`
 }
 
 // Repository represents the metadata of repository
 type Repository struct {
-	CreatedAt time.Time
-	Name      string
-	ARN       string
-	URI       string
+	CreatedAt time.Time `json:"CreatedAt"`
+	Name      string    `json:"Name"`
+	ARN       string    `json:"ARN"`
+	URI       string    `json:"URI"`
 }
 
 // NewClient creates new Client object
@@ -41,73 +99,512 @@ func NewClient(api ecriface.ECRAPI) *Client {
 	}
 }
 
-// GetLogin returns ECR login command
-func (c *Client) GetLogin() (string, error) {
-	resp, err := c.api.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
+// LoginCommand returns docker login command with the credentials
+func (c *Credentials) LoginCommand() string {
+	return fmt.Sprintf("docker login -u %s -p %s %s", c.Username, c.Password, c.ProxyEndpoint)
+}
+
+// ParseImageID parses the given string as image digest if it starts with "sha256:", otherwise as image tag
+func ParseImageID(s string) *ImageID {
+	if strings.HasPrefix(s, digestPrefix) {
+		return &ImageID{
+			Digest: s,
+		}
+	}
+
+	return &ImageID{
+		Tag: s,
+	}
+}
+
+func (id *ImageID) String() string {
+	switch {
+	case id.Digest != "" && id.Tag != "":
+		return fmt.Sprintf("%s (%s)", id.Tag, id.Digest)
+	case id.Digest != "":
+		return id.Digest
+	default:
+		return id.Tag
+	}
+}
+
+func (id *ImageID) identifier() *ecr.ImageIdentifier {
+	identifier := &ecr.ImageIdentifier{}
+
+	if id.Digest != "" {
+		identifier.ImageDigest = aws.String(id.Digest)
+	}
+
+	if id.Tag != "" {
+		identifier.ImageTag = aws.String(id.Tag)
+	}
+
+	return identifier
+}
+
+func newImageID(identifier *ecr.ImageIdentifier) *ImageID {
+	if identifier == nil {
+		return &ImageID{}
+	}
+
+	return &ImageID{
+		Digest: aws.StringValue(identifier.ImageDigest),
+		Tag:    aws.StringValue(identifier.ImageTag),
+	}
+}
+
+// CreateRepository creates new repository
+func (c *Client) CreateRepository(name string) (*Repository, error) {
+	return c.CreateRepositoryWithContext(aws.BackgroundContext(), name)
+}
+
+// CreateRepositoryWithContext creates new repository with the given context
+func (c *Client) CreateRepositoryWithContext(ctx aws.Context, name string) (*Repository, error) {
+	resp, err := c.api.CreateRepositoryWithContext(ctx, &ecr.CreateRepositoryInput{
+		RepositoryName: aws.String(name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create repository")
+	}
+
+	return newRepository(resp.Repository), nil
+}
+
+// DeleteImages deletes the given images from the repository
+// Images which failed to be deleted are reported in DeleteImagesResult.Failures
+func (c *Client) DeleteImages(repository string, imageIDs []*ImageID) (*DeleteImagesResult, error) {
+	return c.DeleteImagesWithContext(aws.BackgroundContext(), repository, imageIDs)
+}
+
+// DeleteImagesWithContext deletes the given images from the repository with the given context
+func (c *Client) DeleteImagesWithContext(ctx aws.Context, repository string, imageIDs []*ImageID) (*DeleteImagesResult, error) {
+	result := &DeleteImagesResult{
+		Deleted:  []*ImageID{},
+		Failures: []*ImageFailure{},
+	}
+
+	for i := 0; i < len(imageIDs); i += batchDeleteImageLimit {
+		end := i + batchDeleteImageLimit
+		if end > len(imageIDs) {
+			end = len(imageIDs)
+		}
+
+		identifiers := []*ecr.ImageIdentifier{}
+
+		for _, id := range imageIDs[i:end] {
+			identifiers = append(identifiers, id.identifier())
+		}
+
+		resp, err := c.api.BatchDeleteImageWithContext(ctx, &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repository),
+			ImageIds:       identifiers,
+		})
+		if err != nil {
+			return result, errors.Wrap(err, "failed to delete images")
+		}
+
+		for _, identifier := range resp.ImageIds {
+			result.Deleted = append(result.Deleted, newImageID(identifier))
+		}
+
+		for _, failure := range resp.Failures {
+			result.Failures = append(result.Failures, &ImageFailure{
+				ImageID: newImageID(failure.ImageId),
+				Code:    aws.StringValue(failure.FailureCode),
+				Reason:  aws.StringValue(failure.FailureReason),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteRepositoryPolicy deletes the policy of the repository
+func (c *Client) DeleteRepositoryPolicy(repository string) error {
+	return c.DeleteRepositoryPolicyWithContext(aws.BackgroundContext(), repository)
+}
+
+// DeleteRepositoryPolicyWithContext deletes the policy of the repository with the given context
+func (c *Client) DeleteRepositoryPolicyWithContext(ctx aws.Context, repository string) error {
+	if _, err := c.api.DeleteRepositoryPolicyWithContext(ctx, &ecr.DeleteRepositoryPolicyInput{
+		RepositoryName: aws.String(repository),
+	}); err != nil {
+		return errors.Wrap(err, "failed to delete repository policy")
+	}
+
+	return nil
+}
+
+// DeleteRepository deletes the repository
+// If force is true, the repository is deleted even if it contains images
+func (c *Client) DeleteRepository(name string, force bool) error {
+	return c.DeleteRepositoryWithContext(aws.BackgroundContext(), name, force)
+}
+
+// DeleteRepositoryWithContext deletes the repository with the given context
+func (c *Client) DeleteRepositoryWithContext(ctx aws.Context, name string, force bool) error {
+	if _, err := c.api.DeleteRepositoryWithContext(ctx, &ecr.DeleteRepositoryInput{
+		RepositoryName: aws.String(name),
+		Force:          aws.Bool(force),
+	}); err != nil {
+		return errors.Wrap(err, "failed to delete repository")
+	}
+
+	return nil
+}
+
+// GetImage returns the metadata of the image specified by tag or digest
+// nil is returned if the image does not exist
+func (c *Client) GetImage(repository, tagOrDigest string) (*Image, error) {
+	return c.GetImageWithContext(aws.BackgroundContext(), repository, tagOrDigest)
+}
+
+// GetImageWithContext returns the metadata of the image specified by tag or digest with the given context
+func (c *Client) GetImageWithContext(ctx aws.Context, repository, tagOrDigest string) (*Image, error) {
+	resp, err := c.api.DescribeImagesWithContext(ctx, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repository),
+		ImageIds: []*ecr.ImageIdentifier{
+			ParseImageID(tagOrDigest).identifier(),
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ecr.ErrCodeImageNotFoundException {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to retrieve image")
+	}
+
+	if len(resp.ImageDetails) == 0 {
+		return nil, nil
+	}
+
+	return newImage(repository, resp.ImageDetails[0]), nil
+}
+
+// GetCredentials returns the credentials to log in to ECR registries
+// If no registry ID is given, the credentials for the default registry are returned
+// Otherwise, the credentials are returned in the same order as the given registry IDs
+func (c *Client) GetCredentials(registryIDs []string) ([]*Credentials, error) {
+	return c.GetCredentialsWithContext(aws.BackgroundContext(), registryIDs)
+}
+
+// GetCredentialsWithContext returns the credentials to log in to ECR registries with the given context
+func (c *Client) GetCredentialsWithContext(ctx aws.Context, registryIDs []string) ([]*Credentials, error) {
+	input := &ecr.GetAuthorizationTokenInput{}
+
+	if len(registryIDs) > 0 {
+		input.RegistryIds = aws.StringSlice(registryIDs)
+	}
+
+	resp, err := c.api.GetAuthorizationTokenWithContext(ctx, input)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to retrieve authorization token")
+		return nil, errors.Wrap(err, "failed to retrieve authorization token")
 	}
 
 	if len(resp.AuthorizationData) == 0 {
-		return "", errors.New("no authorization data found")
+		return nil, errors.New("no authorization data found")
+	}
+
+	if len(registryIDs) == 0 {
+		creds, err := newCredentials(resp.AuthorizationData[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return []*Credentials{creds}, nil
+	}
+
+	credsByID := map[string]*Credentials{}
+
+	for _, authData := range resp.AuthorizationData {
+		creds, err := newCredentials(authData)
+		if err != nil {
+			return nil, err
+		}
+
+		registry, err := ParseRegistryURL(creds.ProxyEndpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		credsByID[registry.ID] = creds
+	}
+
+	result := make([]*Credentials, 0, len(registryIDs))
+
+	for _, id := range registryIDs {
+		creds, ok := credsByID[id]
+		if !ok {
+			return nil, errors.Errorf("no authorization data found for registry %s", id)
+		}
+
+		result = append(result, creds)
 	}
 
-	authData := resp.AuthorizationData[0]
+	return result, nil
+}
+
+// GetLogin returns ECR login commands
+// If no registry ID is given, the login command for the default registry is returned
+func (c *Client) GetLogin(registryIDs []string) ([]string, error) {
+	return c.GetLoginWithContext(aws.BackgroundContext(), registryIDs)
+}
 
-	data, err := base64.StdEncoding.DecodeString(*authData.AuthorizationToken)
+// GetLoginWithContext returns ECR login commands with the given context
+func (c *Client) GetLoginWithContext(ctx aws.Context, registryIDs []string) ([]string, error) {
+	credentials, err := c.GetCredentialsWithContext(ctx, registryIDs)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to decode authorization data")
+		return nil, err
 	}
 
-	ss := strings.Split(string(data), ":")
-	if len(ss) < 2 {
-		return "", errors.Errorf("authorization data must be user:pass. got: %q", string(data))
+	loginCmds := make([]string, 0, len(credentials))
+
+	for _, creds := range credentials {
+		loginCmds = append(loginCmds, creds.LoginCommand())
 	}
 
-	return fmt.Sprintf("docker login -u %s -p %s %s", ss[0], ss[1], *authData.ProxyEndpoint), nil
+	return loginCmds, nil
 }
 
-// ListImages returns the list of stored Docker images
-func (c *Client) ListImages(repository string) ([]*Image, error) {
-	resp, err := c.api.DescribeImages(&ecr.DescribeImagesInput{
+// GetManifest returns the manifest of the image specified by tag or digest
+func (c *Client) GetManifest(repository, tagOrDigest string) (string, error) {
+	return c.GetManifestWithContext(aws.BackgroundContext(), repository, tagOrDigest)
+}
+
+// GetManifestWithContext returns the manifest of the image specified by tag or digest with the given context
+func (c *Client) GetManifestWithContext(ctx aws.Context, repository, tagOrDigest string) (string, error) {
+	return c.getManifest(ctx, repository, tagOrDigest, manifestMediaTypes)
+}
+
+func (c *Client) getManifest(ctx aws.Context, repository, tagOrDigest string, mediaTypes []string) (string, error) {
+	resp, err := c.api.BatchGetImageWithContext(ctx, &ecr.BatchGetImageInput{
+		RepositoryName: aws.String(repository),
+		ImageIds: []*ecr.ImageIdentifier{
+			ParseImageID(tagOrDigest).identifier(),
+		},
+		AcceptedMediaTypes: aws.StringSlice(mediaTypes),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve image")
+	}
+
+	if len(resp.Images) == 0 {
+		if len(resp.Failures) > 0 {
+			failure := resp.Failures[0]
+			return "", errors.Errorf("failed to retrieve image %s: %s: %s", tagOrDigest, aws.StringValue(failure.FailureCode), aws.StringValue(failure.FailureReason))
+		}
+
+		return "", errors.Errorf("image %s not found", tagOrDigest)
+	}
+
+	return aws.StringValue(resp.Images[0].ImageManifest), nil
+}
+
+// GetRepositoryPolicy returns the policy text of the repository
+// Empty string is returned if the repository has no policy
+func (c *Client) GetRepositoryPolicy(repository string) (string, error) {
+	return c.GetRepositoryPolicyWithContext(aws.BackgroundContext(), repository)
+}
+
+// GetRepositoryPolicyWithContext returns the policy text of the repository with the given context
+func (c *Client) GetRepositoryPolicyWithContext(ctx aws.Context, repository string) (string, error) {
+	resp, err := c.api.GetRepositoryPolicyWithContext(ctx, &ecr.GetRepositoryPolicyInput{
 		RepositoryName: aws.String(repository),
 	})
 	if err != nil {
-		return []*Image{}, errors.Wrap(err, "failed to retrieve images")
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ecr.ErrCodeRepositoryPolicyNotFoundException {
+			return "", nil
+		}
+
+		return "", errors.Wrap(err, "failed to retrieve repository policy")
 	}
 
+	return aws.StringValue(resp.PolicyText), nil
+}
+
+// ListImages returns the list of stored Docker images
+// opts can be nil to list all images
+func (c *Client) ListImages(repository string, opts *ListImagesOptions) ([]*Image, error) {
+	return c.ListImagesWithContext(aws.BackgroundContext(), repository, opts)
+}
+
+// ListImagesWithContext returns the list of stored Docker images with the given context
+func (c *Client) ListImagesWithContext(ctx aws.Context, repository string, opts *ListImagesOptions) ([]*Image, error) {
 	images := []*Image{}
 
-	for _, image := range resp.ImageDetails {
-		images = append(images, &Image{
-			Repository:  repository,
-			Digest:      aws.StringValue(image.ImageDigest),
-			Tags:        aws.StringValueSlice(image.ImageTags),
-			SizeInBytes: aws.Int64Value(image.ImageSizeInBytes),
-			PushedAt:    aws.TimeValue(image.ImagePushedAt),
-		})
+	if err := c.ListImagesPagesWithContext(ctx, repository, opts, func(page []*Image, lastPage bool) bool {
+		images = append(images, page...)
+		return true
+	}); err != nil {
+		return []*Image{}, err
 	}
 
 	return images, nil
 }
 
-// ListRepositories returns the list of stored repositories
-func (c *Client) ListRepositories() ([]*Repository, error) {
-	resp, err := c.api.DescribeRepositories(&ecr.DescribeRepositoriesInput{})
+// ListImagesPages iterates over the pages of stored Docker images
+// fn is called with the images in each page, and iteration stops when fn returns false
+func (c *Client) ListImagesPages(repository string, opts *ListImagesOptions, fn func(images []*Image, lastPage bool) bool) error {
+	return c.ListImagesPagesWithContext(aws.BackgroundContext(), repository, opts, fn)
+}
+
+// ListImagesPagesWithContext iterates over the pages of stored Docker images with the given context
+func (c *Client) ListImagesPagesWithContext(ctx aws.Context, repository string, opts *ListImagesOptions, fn func(images []*Image, lastPage bool) bool) error {
+	filter, err := opts.describeImagesFilter()
+	if err != nil {
+		return err
+	}
+
+	match, err := opts.tagMatcher()
 	if err != nil {
-		return []*Repository{}, errors.Wrap(err, "failed to retrieve repositories")
+		return err
+	}
+
+	if err := c.api.DescribeImagesPagesWithContext(ctx, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repository),
+		Filter:         filter,
+	}, func(resp *ecr.DescribeImagesOutput, lastPage bool) bool {
+		images := []*Image{}
+
+		for _, image := range resp.ImageDetails {
+			img := newImage(repository, image)
+
+			if match(img) {
+				images = append(images, img)
+			}
+		}
+
+		return fn(images, lastPage)
+	}); err != nil {
+		return errors.Wrap(err, "failed to retrieve images")
 	}
 
+	return nil
+}
+
+// ListRepositories returns the list of stored repositories
+func (c *Client) ListRepositories() ([]*Repository, error) {
+	return c.ListRepositoriesWithContext(aws.BackgroundContext())
+}
+
+// ListRepositoriesWithContext returns the list of stored repositories with the given context
+func (c *Client) ListRepositoriesWithContext(ctx aws.Context) ([]*Repository, error) {
 	repositories := []*Repository{}
 
-	for _, repository := range resp.Repositories {
-		repositories = append(repositories, &Repository{
-			CreatedAt: aws.TimeValue(repository.CreatedAt),
-			Name:      aws.StringValue(repository.RepositoryName),
-			ARN:       aws.StringValue(repository.RepositoryArn),
-			URI:       aws.StringValue(repository.RepositoryUri),
-		})
+	if err := c.ListRepositoriesPagesWithContext(ctx, func(page []*Repository, lastPage bool) bool {
+		repositories = append(repositories, page...)
+		return true
+	}); err != nil {
+		return []*Repository{}, err
 	}
 
 	return repositories, nil
 }
+
+// ListRepositoriesPages iterates over the pages of stored repositories
+// fn is called with the repositories in each page, and iteration stops when fn returns false
+func (c *Client) ListRepositoriesPages(fn func(repositories []*Repository, lastPage bool) bool) error {
+	return c.ListRepositoriesPagesWithContext(aws.BackgroundContext(), fn)
+}
+
+// ListRepositoriesPagesWithContext iterates over the pages of stored repositories with the given context
+func (c *Client) ListRepositoriesPagesWithContext(ctx aws.Context, fn func(repositories []*Repository, lastPage bool) bool) error {
+	if err := c.api.DescribeRepositoriesPagesWithContext(ctx, &ecr.DescribeRepositoriesInput{}, func(resp *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+		repositories := []*Repository{}
+
+		for _, repository := range resp.Repositories {
+			repositories = append(repositories, newRepository(repository))
+		}
+
+		return fn(repositories, lastPage)
+	}); err != nil {
+		return errors.Wrap(err, "failed to retrieve repositories")
+	}
+
+	return nil
+}
+
+// SetRepositoryPolicy sets the policy of the repository
+func (c *Client) SetRepositoryPolicy(repository, policy string) error {
+	return c.SetRepositoryPolicyWithContext(aws.BackgroundContext(), repository, policy)
+}
+
+// SetRepositoryPolicyWithContext sets the policy of the repository with the given context
+func (c *Client) SetRepositoryPolicyWithContext(ctx aws.Context, repository, policy string) error {
+	if _, err := c.api.SetRepositoryPolicyWithContext(ctx, &ecr.SetRepositoryPolicyInput{
+		RepositoryName: aws.String(repository),
+		PolicyText:     aws.String(policy),
+	}); err != nil {
+		return errors.Wrap(err, "failed to set repository policy")
+	}
+
+	return nil
+}
+
+// TagImage adds the new tag to the source image specified by tag or digest without pulling image
+// If the new tag already points to another image, the tag is moved to the source image
+// ErrImageAlreadyExists is returned if the new tag already points to the source image
+func (c *Client) TagImage(repository, source, newTag string) error {
+	return c.TagImageWithContext(aws.BackgroundContext(), repository, source, newTag)
+}
+
+// TagImageWithContext adds the new tag to the source image with the given context
+func (c *Client) TagImageWithContext(ctx aws.Context, repository, source, newTag string) error {
+	manifest, err := c.getManifest(ctx, repository, source, tagManifestMediaTypes)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.api.PutImageWithContext(ctx, &ecr.PutImageInput{
+		RepositoryName: aws.String(repository),
+		ImageManifest:  aws.String(manifest),
+		ImageTag:       aws.String(newTag),
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ecr.ErrCodeImageAlreadyExistsException {
+			return ErrImageAlreadyExists
+		}
+
+		return errors.Wrap(err, "failed to put image")
+	}
+
+	return nil
+}
+
+func newCredentials(authData *ecr.AuthorizationData) (*Credentials, error) {
+	data, err := base64.StdEncoding.DecodeString(aws.StringValue(authData.AuthorizationToken))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode authorization data")
+	}
+
+	ss := strings.SplitN(string(data), ":", 2)
+	if len(ss) < 2 {
+		return nil, errors.Errorf("authorization data must be user:pass. got: %q", string(data))
+	}
+
+	return &Credentials{
+		Username:      ss[0],
+		Password:      ss[1],
+		ProxyEndpoint: aws.StringValue(authData.ProxyEndpoint),
+		ExpiresAt:     aws.TimeValue(authData.ExpiresAt),
+	}, nil
+}
+
+func newImage(repository string, image *ecr.ImageDetail) *Image {
+	return &Image{
+		Repository:  repository,
+		Digest:      aws.StringValue(image.ImageDigest),
+		Tags:        aws.StringValueSlice(image.ImageTags),
+		SizeInBytes: aws.Int64Value(image.ImageSizeInBytes),
+		PushedAt:    aws.TimeValue(image.ImagePushedAt),
+	}
+}
+
+func newRepository(repository *ecr.Repository) *Repository {
+	return &Repository{
+		CreatedAt: aws.TimeValue(repository.CreatedAt),
+		Name:      aws.StringValue(repository.RepositoryName),
+		ARN:       aws.StringValue(repository.RepositoryArn),
+		URI:       aws.StringValue(repository.RepositoryUri),
+	}
+}
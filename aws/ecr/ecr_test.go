@@ -1,22 +1,430 @@
 package ecr
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/dtan4/ecrcli/aws/mock"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 )
 
+func TestParseImageID(t *testing.T) {
+	testcases := []struct {
+		s        string
+		expected *ImageID
+	}{
+		{
+			s: "sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+			expected: &ImageID{
+				Digest: "sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+			},
+		},
+		{
+			s: "latest",
+			expected: &ImageID{
+				Tag: "latest",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		got := ParseImageID(tc.s)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("image ID does not match. expected: %#v, got: %#v", tc.expected, got)
+		}
+	}
+}
+
+func TestCreateRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().CreateRepositoryWithContext(gomock.Any(), &ecr.CreateRepositoryInput{
+		RepositoryName: aws.String("foo"),
+	}).Return(&ecr.CreateRepositoryOutput{
+		Repository: &ecr.Repository{
+			RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/foo"),
+			RegistryId:     aws.String("012345678910"),
+			RepositoryName: aws.String("foo"),
+			RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/foo"),
+			CreatedAt:      aws.Time(createdAt),
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.CreateRepository("foo")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	expected := &Repository{
+		CreatedAt: createdAt,
+		Name:      "foo",
+		ARN:       "arn:aws:ecr:us-east-1:012345678910:repository/foo",
+		URI:       "012345678910.dkr.ecr.us-east-1.amazonaws.com/foo",
+	}
+
+	if !repositoryEquals(got, expected) {
+		t.Errorf("expected:\n%#v, got:\n%#v", expected, got)
+	}
+}
+
+func TestDeleteImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+
+	imageIDs := []*ImageID{}
+	firstIdentifiers := []*ecr.ImageIdentifier{}
+	secondIdentifiers := []*ecr.ImageIdentifier{}
+
+	for i := 0; i < 150; i++ {
+		tag := fmt.Sprintf("tag-%d", i)
+		imageIDs = append(imageIDs, &ImageID{
+			Tag: tag,
+		})
+
+		if i < 100 {
+			firstIdentifiers = append(firstIdentifiers, &ecr.ImageIdentifier{
+				ImageTag: aws.String(tag),
+			})
+		} else {
+			secondIdentifiers = append(secondIdentifiers, &ecr.ImageIdentifier{
+				ImageTag: aws.String(tag),
+			})
+		}
+	}
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().BatchDeleteImageWithContext(gomock.Any(), &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repository),
+			ImageIds:       firstIdentifiers,
+		}).Return(&ecr.BatchDeleteImageOutput{
+			ImageIds: firstIdentifiers,
+		}, nil),
+		api.EXPECT().BatchDeleteImageWithContext(gomock.Any(), &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repository),
+			ImageIds:       secondIdentifiers,
+		}).Return(&ecr.BatchDeleteImageOutput{
+			ImageIds: secondIdentifiers[1:],
+			Failures: []*ecr.ImageFailure{
+				&ecr.ImageFailure{
+					ImageId:       secondIdentifiers[0],
+					FailureCode:   aws.String(ecr.ImageFailureCodeImageNotFound),
+					FailureReason: aws.String("Requested image not found"),
+				},
+			},
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.DeleteImages(repository, imageIDs)
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if len(got.Deleted) != 149 {
+		t.Errorf("number of deleted images does not match. expected: 149, got: %d", len(got.Deleted))
+	}
+
+	expectedFailures := []*ImageFailure{
+		&ImageFailure{
+			ImageID: &ImageID{
+				Tag: "tag-100",
+			},
+			Code:   ecr.ImageFailureCodeImageNotFound,
+			Reason: "Requested image not found",
+		},
+	}
+
+	if !reflect.DeepEqual(got.Failures, expectedFailures) {
+		t.Errorf("failures do not match. expected: %#v, got: %#v", expectedFailures, got.Failures)
+	}
+}
+
+func TestDeleteImages_partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+
+	imageIDs := []*ImageID{}
+	firstIdentifiers := []*ecr.ImageIdentifier{}
+
+	for i := 0; i < 150; i++ {
+		tag := fmt.Sprintf("tag-%d", i)
+		imageIDs = append(imageIDs, &ImageID{
+			Tag: tag,
+		})
+
+		if i < 100 {
+			firstIdentifiers = append(firstIdentifiers, &ecr.ImageIdentifier{
+				ImageTag: aws.String(tag),
+			})
+		}
+	}
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().BatchDeleteImageWithContext(gomock.Any(), &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repository),
+			ImageIds:       firstIdentifiers,
+		}).Return(&ecr.BatchDeleteImageOutput{
+			ImageIds: firstIdentifiers,
+		}, nil),
+		api.EXPECT().BatchDeleteImageWithContext(gomock.Any(), gomock.Any()).Return(nil, awserr.New("ThrottlingException", "Rate exceeded", nil)),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.DeleteImages(repository, imageIDs)
+	if err == nil {
+		t.Errorf("error should be raised")
+	}
+
+	if got == nil || len(got.Deleted) != 100 {
+		t.Errorf("images deleted before the error should be returned. got: %#v", got)
+	}
+}
+
+func TestDeleteRepositoryPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DeleteRepositoryPolicyWithContext(gomock.Any(), &ecr.DeleteRepositoryPolicyInput{
+		RepositoryName: aws.String("foo"),
+	}).Return(&ecr.DeleteRepositoryPolicyOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	if err := client.DeleteRepositoryPolicy("foo"); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+}
+
+func TestDeleteRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DeleteRepositoryWithContext(gomock.Any(), &ecr.DeleteRepositoryInput{
+		RepositoryName: aws.String("foo"),
+		Force:          aws.Bool(true),
+	}).Return(&ecr.DeleteRepositoryOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	if err := client.DeleteRepository("foo", true); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+}
+
+func TestGetImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+	pushedAt := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().DescribeImagesWithContext(gomock.Any(), &ecr.DescribeImagesInput{
+			RepositoryName: aws.String(repository),
+			ImageIds: []*ecr.ImageIdentifier{
+				&ecr.ImageIdentifier{
+					ImageTag: aws.String("latest"),
+				},
+			},
+		}).Return(&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String(repository),
+					ImageDigest:    aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
+					ImageTags: []*string{
+						aws.String("latest"),
+					},
+					ImageSizeInBytes: aws.Int64(186629610),
+					ImagePushedAt:    aws.Time(pushedAt),
+				},
+			},
+		}, nil),
+		api.EXPECT().DescribeImagesWithContext(gomock.Any(), &ecr.DescribeImagesInput{
+			RepositoryName: aws.String(repository),
+			ImageIds: []*ecr.ImageIdentifier{
+				&ecr.ImageIdentifier{
+					ImageTag: aws.String("notfound"),
+				},
+			},
+		}).Return(nil, awserr.New(ecr.ErrCodeImageNotFoundException, "The image requested does not exist in the specified repository.", nil)),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.GetImage(repository, "latest")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	expected := &Image{
+		Repository: repository,
+		Digest:     "sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+		Tags: []string{
+			"latest",
+		},
+		SizeInBytes: 186629610,
+		PushedAt:    pushedAt,
+	}
+
+	if got == nil || !imageEquals(got, expected) {
+		t.Errorf("expected:\n%#v, got:\n%#v", expected, got)
+	}
+
+	got, err = client.GetImage(repository, "notfound")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if got != nil {
+		t.Errorf("image should be nil. got: %#v", got)
+	}
+}
+
+func TestGetCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiresAt := time.Date(2017, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().GetAuthorizationTokenWithContext(gomock.Any(), &ecr.GetAuthorizationTokenInput{}).Return(&ecr.GetAuthorizationTokenOutput{
+		AuthorizationData: []*ecr.AuthorizationData{
+			&ecr.AuthorizationData{
+				AuthorizationToken: aws.String("QVdTOnBhc3M6d29yZA=="),
+				ExpiresAt:          aws.Time(expiresAt),
+				ProxyEndpoint:      aws.String("https://012345678910.dkr.ecr.us-east-1.amazonaws.com"),
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*Credentials{
+		&Credentials{
+			Username:      "AWS",
+			Password:      "pass:word",
+			ProxyEndpoint: "https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+			ExpiresAt:     expiresAt,
+		},
+	}
+
+	got, err := client.GetCredentials(nil)
+	if err != nil {
+		t.Errorf("error should not be raised: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("credentials does not match. expected: %#v, got: %#v", expected, got)
+	}
+}
+
+func TestGetCredentials_registryIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().GetAuthorizationTokenWithContext(gomock.Any(), &ecr.GetAuthorizationTokenInput{
+		RegistryIds: aws.StringSlice([]string{"012345678910", "109876543210"}),
+	}).Return(&ecr.GetAuthorizationTokenOutput{
+		AuthorizationData: []*ecr.AuthorizationData{
+			&ecr.AuthorizationData{
+				AuthorizationToken: aws.String("QVdTOmJhcg=="),
+				ProxyEndpoint:      aws.String("https://109876543210.dkr.ecr.us-east-1.amazonaws.com"),
+			},
+			&ecr.AuthorizationData{
+				AuthorizationToken: aws.String("QVdTOmZvbw=="),
+				ProxyEndpoint:      aws.String("https://012345678910.dkr.ecr.us-east-1.amazonaws.com"),
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	expected := []*Credentials{
+		&Credentials{
+			Username:      "AWS",
+			Password:      "foo",
+			ProxyEndpoint: "https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+		},
+		&Credentials{
+			Username:      "AWS",
+			Password:      "bar",
+			ProxyEndpoint: "https://109876543210.dkr.ecr.us-east-1.amazonaws.com",
+		},
+	}
+
+	got, err := client.GetCredentials([]string{"012345678910", "109876543210"})
+	if err != nil {
+		t.Errorf("error should not be raised: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("credentials does not match. expected: %#v, got: %#v", expected, got)
+	}
+}
+
+func TestGetCredentials_invalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().GetAuthorizationTokenWithContext(gomock.Any(), &ecr.GetAuthorizationTokenInput{}).Return(&ecr.GetAuthorizationTokenOutput{
+		AuthorizationData: []*ecr.AuthorizationData{
+			&ecr.AuthorizationData{
+				AuthorizationToken: aws.String("dXNlcm5hbWU="),
+				ProxyEndpoint:      aws.String("https://012345678910.dkr.ecr.us-east-1.amazonaws.com"),
+			},
+		},
+	}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	if _, err := client.GetCredentials(nil); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
+
 func TestGetLogin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	api := mock.NewMockECRAPI(ctrl)
-	api.EXPECT().GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{}).Return(&ecr.GetAuthorizationTokenOutput{
+	api.EXPECT().GetAuthorizationTokenWithContext(gomock.Any(), &ecr.GetAuthorizationTokenInput{}).Return(&ecr.GetAuthorizationTokenOutput{
 		AuthorizationData: []*ecr.AuthorizationData{
 			&ecr.AuthorizationData{
 				AuthorizationToken: aws.String("dXNlcm5hbWU6cGFzc3dvcmQ="),
@@ -28,18 +436,126 @@ func TestGetLogin(t *testing.T) {
 		api: api,
 	}
 
-	expected := "docker login -u username -p password https://012345678910.dkr.ecr.us-east-1.amazonaws.com"
+	expected := []string{
+		"docker login -u username -p password https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+	}
 
-	got, err := client.GetLogin()
+	got, err := client.GetLogin(nil)
 	if err != nil {
 		t.Errorf("error should not be raised: %s", err)
 	}
 
-	if got != expected {
+	if !reflect.DeepEqual(got, expected) {
 		t.Errorf("output does not match. expected: %q, got: %q", expected, got)
 	}
 }
 
+func TestGetManifest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	manifest := `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().BatchGetImageWithContext(gomock.Any(), &ecr.BatchGetImageInput{
+			RepositoryName: aws.String("foo"),
+			ImageIds: []*ecr.ImageIdentifier{
+				&ecr.ImageIdentifier{
+					ImageTag: aws.String("latest"),
+				},
+			},
+			AcceptedMediaTypes: aws.StringSlice(manifestMediaTypes),
+		}).Return(&ecr.BatchGetImageOutput{
+			Images: []*ecr.Image{
+				&ecr.Image{
+					ImageId: &ecr.ImageIdentifier{
+						ImageDigest: aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
+						ImageTag:    aws.String("latest"),
+					},
+					ImageManifest: aws.String(manifest),
+				},
+			},
+		}, nil),
+		api.EXPECT().BatchGetImageWithContext(gomock.Any(), &ecr.BatchGetImageInput{
+			RepositoryName: aws.String("foo"),
+			ImageIds: []*ecr.ImageIdentifier{
+				&ecr.ImageIdentifier{
+					ImageTag: aws.String("notfound"),
+				},
+			},
+			AcceptedMediaTypes: aws.StringSlice(manifestMediaTypes),
+		}).Return(&ecr.BatchGetImageOutput{
+			Failures: []*ecr.ImageFailure{
+				&ecr.ImageFailure{
+					ImageId: &ecr.ImageIdentifier{
+						ImageTag: aws.String("notfound"),
+					},
+					FailureCode:   aws.String(ecr.ImageFailureCodeImageNotFound),
+					FailureReason: aws.String("Requested image not found"),
+				},
+			},
+		}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.GetManifest("foo", "latest")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if got != manifest {
+		t.Errorf("manifest does not match. expected: %q, got: %q", manifest, got)
+	}
+
+	if _, err := client.GetManifest("foo", "notfound"); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
+
+func TestGetRepositoryPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := `{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::109876543210:root"},"Action":"ecr:BatchGetImage"}]}`
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().GetRepositoryPolicyWithContext(gomock.Any(), &ecr.GetRepositoryPolicyInput{
+			RepositoryName: aws.String("foo"),
+		}).Return(&ecr.GetRepositoryPolicyOutput{
+			RepositoryName: aws.String("foo"),
+			PolicyText:     aws.String(policy),
+		}, nil),
+		api.EXPECT().GetRepositoryPolicyWithContext(gomock.Any(), &ecr.GetRepositoryPolicyInput{
+			RepositoryName: aws.String("bar"),
+		}).Return(nil, awserr.New(ecr.ErrCodeRepositoryPolicyNotFoundException, "Repository policy does not exist", nil)),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	got, err := client.GetRepositoryPolicy("foo")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if got != policy {
+		t.Errorf("policy does not match. expected: %q, got: %q", policy, got)
+	}
+
+	got, err = client.GetRepositoryPolicy("bar")
+	if err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if got != "" {
+		t.Errorf("policy should be empty. got: %q", got)
+	}
+}
+
 func TestListImages(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -47,47 +563,62 @@ func TestListImages(t *testing.T) {
 	repository := "repository"
 	pushedAt := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
 
-	api := mock.NewMockECRAPI(ctrl)
-	api.EXPECT().DescribeImages(&ecr.DescribeImagesInput{
-		RepositoryName: aws.String(repository),
-	}).Return(&ecr.DescribeImagesOutput{
-		ImageDetails: []*ecr.ImageDetail{
-			&ecr.ImageDetail{
-				RegistryId:     aws.String("012345678910"),
-				RepositoryName: aws.String("repository"),
-				ImageDigest:    aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
-				ImageTags: []*string{
-					aws.String("latest"),
+	pages := []*ecr.DescribeImagesOutput{
+		&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String("repository"),
+					ImageDigest:    aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
+					ImageTags: []*string{
+						aws.String("latest"),
+					},
+					ImageSizeInBytes: aws.Int64(186629610),
+					ImagePushedAt:    aws.Time(pushedAt),
 				},
-				ImageSizeInBytes: aws.Int64(186629610),
-				ImagePushedAt:    aws.Time(pushedAt),
-			},
-			&ecr.ImageDetail{
-				RegistryId:     aws.String("012345678910"),
-				RepositoryName: aws.String("repository"),
-				ImageDigest:    aws.String("sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310"),
-				ImageTags: []*string{
-					aws.String("foo"),
-					aws.String("bar"),
+				&ecr.ImageDetail{
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String("repository"),
+					ImageDigest:    aws.String("sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310"),
+					ImageTags: []*string{
+						aws.String("foo"),
+						aws.String("bar"),
+					},
+					ImageSizeInBytes: aws.Int64(178952648),
+					ImagePushedAt:    aws.Time(pushedAt),
 				},
-				ImageSizeInBytes: aws.Int64(178952648),
-				ImagePushedAt:    aws.Time(pushedAt),
 			},
-			&ecr.ImageDetail{
-				RegistryId:       aws.String("012345678910"),
-				RepositoryName:   aws.String("repository"),
-				ImageDigest:      aws.String("sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93"),
-				ImageTags:        []*string{},
-				ImageSizeInBytes: aws.Int64(186632884),
-				ImagePushedAt:    aws.Time(pushedAt),
+			NextToken: aws.String("token"),
+		},
+		&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					RegistryId:       aws.String("012345678910"),
+					RepositoryName:   aws.String("repository"),
+					ImageDigest:      aws.String("sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93"),
+					ImageTags:        []*string{},
+					ImageSizeInBytes: aws.Int64(186632884),
+					ImagePushedAt:    aws.Time(pushedAt),
+				},
 			},
 		},
-	}, nil)
+	}
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DescribeImagesPagesWithContext(gomock.Any(), &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repository),
+	}, gomock.Any()).Do(func(ctx aws.Context, input *ecr.DescribeImagesInput, fn func(*ecr.DescribeImagesOutput, bool) bool) {
+		for i, page := range pages {
+			if !fn(page, i == len(pages)-1) {
+				return
+			}
+		}
+	}).Return(nil)
 	client := &Client{
 		api: api,
 	}
 
-	got, err := client.ListImages(repository)
+	got, err := client.ListImages(repository, nil)
 	if err != nil {
 		t.Errorf("got error: %s", err)
 	}
@@ -121,6 +652,10 @@ func TestListImages(t *testing.T) {
 		},
 	}
 
+	if len(got) != len(expected) {
+		t.Fatalf("number of images does not match. expected: %d, got: %d", len(expected), len(got))
+	}
+
 	for i := range got {
 		if !imageEquals(got[i], expected[i]) {
 			t.Errorf("expected[%d]:\n%#v, got[%d]:\n%#v", i, expected[i], i, got[i])
@@ -128,6 +663,225 @@ func TestListImages(t *testing.T) {
 	}
 }
 
+func TestListImages_options(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+
+	page := &ecr.DescribeImagesOutput{
+		ImageDetails: []*ecr.ImageDetail{
+			&ecr.ImageDetail{
+				ImageDigest: aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
+				ImageTags: []*string{
+					aws.String("latest"),
+					aws.String("release-2"),
+				},
+			},
+			&ecr.ImageDetail{
+				ImageDigest: aws.String("sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310"),
+				ImageTags: []*string{
+					aws.String("release-1"),
+				},
+			},
+			&ecr.ImageDetail{
+				ImageDigest: aws.String("sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93"),
+				ImageTags: []*string{
+					aws.String("pr-123"),
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		opts     *ListImagesOptions
+		filter   *ecr.DescribeImagesFilter
+		expected []string
+	}{
+		{
+			opts: &ListImagesOptions{
+				TagStatus:  TagStatusTagged,
+				TagPattern: "release-*",
+			},
+			filter: &ecr.DescribeImagesFilter{
+				TagStatus: aws.String(ecr.TagStatusTagged),
+			},
+			expected: []string{
+				"sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+				"sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310",
+			},
+		},
+		{
+			opts: &ListImagesOptions{
+				TagStatus:  TagStatusAny,
+				TagPattern: "/^(latest|pr-[0-9]+)$/",
+			},
+			filter: nil,
+			expected: []string{
+				"sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+				"sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		api := mock.NewMockECRAPI(ctrl)
+		api.EXPECT().DescribeImagesPagesWithContext(gomock.Any(), &ecr.DescribeImagesInput{
+			RepositoryName: aws.String(repository),
+			Filter:         tc.filter,
+		}, gomock.Any()).Do(func(ctx aws.Context, input *ecr.DescribeImagesInput, fn func(*ecr.DescribeImagesOutput, bool) bool) {
+			fn(page, true)
+		}).Return(nil)
+		client := &Client{
+			api: api,
+		}
+
+		images, err := client.ListImages(repository, tc.opts)
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		got := []string{}
+
+		for _, image := range images {
+			got = append(got, image.Digest)
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("digests do not match. opts: %#v, expected: %q, got: %q", tc.opts, tc.expected, got)
+		}
+	}
+}
+
+func TestListImages_invalidOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock.NewMockECRAPI(ctrl)
+	client := &Client{
+		api: api,
+	}
+
+	testcases := []*ListImagesOptions{
+		&ListImagesOptions{
+			TagStatus: "unknown",
+		},
+		&ListImagesOptions{
+			TagPattern: "release-[",
+		},
+		&ListImagesOptions{
+			TagPattern: "/release-(/",
+		},
+	}
+
+	for _, opts := range testcases {
+		if _, err := client.ListImages("repository", opts); err == nil {
+			t.Errorf("error should be raised. opts: %#v", opts)
+		}
+	}
+}
+
+func TestListImagesPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+
+	pages := []*ecr.DescribeImagesOutput{
+		&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					ImageDigest: aws.String("sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8"),
+				},
+			},
+			NextToken: aws.String("token1"),
+		},
+		&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					ImageDigest: aws.String("sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310"),
+				},
+			},
+			NextToken: aws.String("token2"),
+		},
+		&ecr.DescribeImagesOutput{
+			ImageDetails: []*ecr.ImageDetail{
+				&ecr.ImageDetail{
+					ImageDigest: aws.String("sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93"),
+				},
+			},
+		},
+	}
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DescribeImagesPagesWithContext(gomock.Any(), &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repository),
+	}, gomock.Any()).Do(func(ctx aws.Context, input *ecr.DescribeImagesInput, fn func(*ecr.DescribeImagesOutput, bool) bool) {
+		for i, page := range pages {
+			if !fn(page, i == len(pages)-1) {
+				return
+			}
+		}
+	}).Return(nil)
+	client := &Client{
+		api: api,
+	}
+
+	got := []string{}
+
+	if err := client.ListImagesPages(repository, nil, func(images []*Image, lastPage bool) bool {
+		for _, image := range images {
+			got = append(got, image.Digest)
+		}
+
+		return len(got) < 2
+	}); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	expected := []string{
+		"sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+		"sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310",
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("digests do not match. expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestListImagesWithContext_canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DescribeImagesPagesWithContext(ctx, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repository),
+	}, gomock.Any()).Return(awserr.New(request.CanceledErrorCode, "request context canceled", ctx.Err()))
+	client := &Client{
+		api: api,
+	}
+
+	_, err := client.ListImagesWithContext(ctx, repository, nil)
+	if err == nil {
+		t.Fatalf("error should be raised")
+	}
+
+	aerr, ok := errors.Cause(err).(awserr.Error)
+	if !ok {
+		t.Fatalf("error should be awserr.Error. got: %#v", err)
+	}
+
+	if aerr.Code() != request.CanceledErrorCode {
+		t.Errorf("error code does not match. expected: %q, got: %q", request.CanceledErrorCode, aerr.Code())
+	}
+}
+
 func imageEquals(a, b *Image) bool {
 	return a.Repository == b.Repository &&
 		a.Digest == b.Digest &&
@@ -142,32 +896,47 @@ func TestListRepositories(t *testing.T) {
 
 	createdAt := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
 
-	api := mock.NewMockECRAPI(ctrl)
-	api.EXPECT().DescribeRepositories(&ecr.DescribeRepositoriesInput{}).Return(&ecr.DescribeRepositoriesOutput{
-		Repositories: []*ecr.Repository{
-			&ecr.Repository{
-				RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/foo"),
-				RegistryId:     aws.String("012345678910"),
-				RepositoryName: aws.String("foo"),
-				RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/foo"),
-				CreatedAt:      aws.Time(createdAt),
-			},
-			&ecr.Repository{
-				RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/bar"),
-				RegistryId:     aws.String("012345678910"),
-				RepositoryName: aws.String("bar"),
-				RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/bar"),
-				CreatedAt:      aws.Time(createdAt),
+	pages := []*ecr.DescribeRepositoriesOutput{
+		&ecr.DescribeRepositoriesOutput{
+			Repositories: []*ecr.Repository{
+				&ecr.Repository{
+					RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/foo"),
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String("foo"),
+					RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/foo"),
+					CreatedAt:      aws.Time(createdAt),
+				},
+				&ecr.Repository{
+					RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/bar"),
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String("bar"),
+					RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/bar"),
+					CreatedAt:      aws.Time(createdAt),
+				},
 			},
-			&ecr.Repository{
-				RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/baz"),
-				RegistryId:     aws.String("012345678910"),
-				RepositoryName: aws.String("baz"),
-				RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/baz"),
-				CreatedAt:      aws.Time(createdAt),
+			NextToken: aws.String("token"),
+		},
+		&ecr.DescribeRepositoriesOutput{
+			Repositories: []*ecr.Repository{
+				&ecr.Repository{
+					RepositoryArn:  aws.String("arn:aws:ecr:us-east-1:012345678910:repository/baz"),
+					RegistryId:     aws.String("012345678910"),
+					RepositoryName: aws.String("baz"),
+					RepositoryUri:  aws.String("012345678910.dkr.ecr.us-east-1.amazonaws.com/baz"),
+					CreatedAt:      aws.Time(createdAt),
+				},
 			},
 		},
-	}, nil)
+	}
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().DescribeRepositoriesPagesWithContext(gomock.Any(), &ecr.DescribeRepositoriesInput{}, gomock.Any()).Do(func(ctx aws.Context, input *ecr.DescribeRepositoriesInput, fn func(*ecr.DescribeRepositoriesOutput, bool) bool) {
+		for i, page := range pages {
+			if !fn(page, i == len(pages)-1) {
+				return
+			}
+		}
+	}).Return(nil)
 	client := &Client{
 		api: api,
 	}
@@ -198,6 +967,10 @@ func TestListRepositories(t *testing.T) {
 		},
 	}
 
+	if len(got) != len(expected) {
+		t.Fatalf("number of repositories does not match. expected: %d, got: %d", len(expected), len(got))
+	}
+
 	for i := range got {
 		if !repositoryEquals(got[i], expected[i]) {
 			t.Errorf("expected[%d]:\n%#v, got[%d]:\n%#v", i, expected[i], i, got[i])
@@ -208,3 +981,117 @@ func TestListRepositories(t *testing.T) {
 func repositoryEquals(a, b *Repository) bool {
 	return a.CreatedAt.Equal(b.CreatedAt) && a.ARN == b.ARN && a.Name == b.Name && a.URI == b.URI
 }
+
+func TestSetRepositoryPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := `{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::109876543210:root"},"Action":"ecr:BatchGetImage"}]}`
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().SetRepositoryPolicyWithContext(gomock.Any(), &ecr.SetRepositoryPolicyInput{
+		RepositoryName: aws.String("foo"),
+		PolicyText:     aws.String(policy),
+	}).Return(&ecr.SetRepositoryPolicyOutput{}, nil)
+	client := &Client{
+		api: api,
+	}
+
+	if err := client.SetRepositoryPolicy("foo", policy); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+}
+
+func TestTagImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+	manifest := `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`
+
+	api := mock.NewMockECRAPI(ctrl)
+	api.EXPECT().BatchGetImageWithContext(gomock.Any(), &ecr.BatchGetImageInput{
+		RepositoryName: aws.String(repository),
+		ImageIds: []*ecr.ImageIdentifier{
+			&ecr.ImageIdentifier{
+				ImageTag: aws.String("sha-abc123"),
+			},
+		},
+		AcceptedMediaTypes: aws.StringSlice(tagManifestMediaTypes),
+	}).Return(&ecr.BatchGetImageOutput{
+		Images: []*ecr.Image{
+			&ecr.Image{
+				ImageManifest: aws.String(manifest),
+			},
+		},
+	}, nil).Times(2)
+	gomock.InOrder(
+		api.EXPECT().PutImageWithContext(gomock.Any(), &ecr.PutImageInput{
+			RepositoryName: aws.String(repository),
+			ImageManifest:  aws.String(manifest),
+			ImageTag:       aws.String("production"),
+		}).Return(&ecr.PutImageOutput{}, nil),
+		api.EXPECT().PutImageWithContext(gomock.Any(), &ecr.PutImageInput{
+			RepositoryName: aws.String(repository),
+			ImageManifest:  aws.String(manifest),
+			ImageTag:       aws.String("production"),
+		}).Return(nil, awserr.New(ecr.ErrCodeImageAlreadyExistsException, "Image with digest already exists", nil)),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	if err := client.TagImage(repository, "sha-abc123", "production"); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+
+	if err := client.TagImage(repository, "sha-abc123", "production"); err != ErrImageAlreadyExists {
+		t.Errorf("ErrImageAlreadyExists should be returned. got: %#v", err)
+	}
+}
+
+func TestTagImage_schema1(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := "repository"
+	manifest := `{"schemaVersion":1,"name":"repository","tag":"old","fsLayers":[],"signatures":[{"protected":"eyJmb3JtYXRMZW5ndGgiOjF9"}]}`
+
+	api := mock.NewMockECRAPI(ctrl)
+	gomock.InOrder(
+		api.EXPECT().BatchGetImageWithContext(gomock.Any(), &ecr.BatchGetImageInput{
+			RepositoryName: aws.String(repository),
+			ImageIds: []*ecr.ImageIdentifier{
+				&ecr.ImageIdentifier{
+					ImageTag: aws.String("old"),
+				},
+			},
+			AcceptedMediaTypes: aws.StringSlice([]string{
+				"application/vnd.docker.distribution.manifest.v1+json",
+				"application/vnd.docker.distribution.manifest.v1+prettyjws",
+				"application/vnd.docker.distribution.manifest.v2+json",
+				"application/vnd.docker.distribution.manifest.list.v2+json",
+				"application/vnd.oci.image.manifest.v1+json",
+				"application/vnd.oci.image.index.v1+json",
+			}),
+		}).Return(&ecr.BatchGetImageOutput{
+			Images: []*ecr.Image{
+				&ecr.Image{
+					ImageManifest: aws.String(manifest),
+				},
+			},
+		}, nil),
+		api.EXPECT().PutImageWithContext(gomock.Any(), &ecr.PutImageInput{
+			RepositoryName: aws.String(repository),
+			ImageManifest:  aws.String(manifest),
+			ImageTag:       aws.String("new"),
+		}).Return(&ecr.PutImageOutput{}, nil),
+	)
+	client := &Client{
+		api: api,
+	}
+
+	if err := client.TagImage(repository, "old", "new"); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+}
@@ -0,0 +1,90 @@
+package ecr
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TagStatusAny selects both tagged and untagged images
+	TagStatusAny = "any"
+	// TagStatusTagged selects tagged images only
+	TagStatusTagged = "tagged"
+	// TagStatusUntagged selects untagged images only
+	TagStatusUntagged = "untagged"
+)
+
+// ListImagesOptions represents the filter options of ListImages
+type ListImagesOptions struct {
+	// TagStatus is one of TagStatusAny, TagStatusTagged and TagStatusUntagged. Empty means TagStatusAny
+	TagStatus string
+	// TagPattern selects images which have at least one tag matching the pattern
+	// The pattern is regular expression if it is enclosed in slashes (e.g. /^v[0-9]+$/), otherwise glob (e.g. release-*)
+	TagPattern string
+}
+
+// describeImagesFilter returns the filter passed to DescribeImages API
+func (o *ListImagesOptions) describeImagesFilter() (*ecr.DescribeImagesFilter, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	switch o.TagStatus {
+	case "", TagStatusAny:
+		return nil, nil
+	case TagStatusTagged:
+		return &ecr.DescribeImagesFilter{
+			TagStatus: aws.String(ecr.TagStatusTagged),
+		}, nil
+	case TagStatusUntagged:
+		return &ecr.DescribeImagesFilter{
+			TagStatus: aws.String(ecr.TagStatusUntagged),
+		}, nil
+	default:
+		return nil, errors.Errorf("tag status must be one of %s, %s and %s. got: %q", TagStatusAny, TagStatusTagged, TagStatusUntagged, o.TagStatus)
+	}
+}
+
+// tagMatcher returns the function which reports whether the image has a tag matching TagPattern
+func (o *ListImagesOptions) tagMatcher() (func(image *Image) bool, error) {
+	if o == nil || o.TagPattern == "" {
+		return func(image *Image) bool {
+			return true
+		}, nil
+	}
+
+	var match func(tag string) bool
+
+	if len(o.TagPattern) >= 2 && strings.HasPrefix(o.TagPattern, "/") && strings.HasSuffix(o.TagPattern, "/") {
+		re, err := regexp.Compile(o.TagPattern[1 : len(o.TagPattern)-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid tag pattern")
+		}
+
+		match = re.MatchString
+	} else {
+		if _, err := path.Match(o.TagPattern, ""); err != nil {
+			return nil, errors.Wrap(err, "invalid tag pattern")
+		}
+
+		match = func(tag string) bool {
+			ok, _ := path.Match(o.TagPattern, tag)
+			return ok
+		}
+	}
+
+	return func(image *Image) bool {
+		for _, tag := range image.Tags {
+			if match(tag) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
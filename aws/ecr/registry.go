@@ -0,0 +1,47 @@
+package ecr
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// registryHostRegexp matches ECR registry hostname (e.g. 012345678910.dkr.ecr.us-east-1.amazonaws.com)
+	registryHostRegexp = regexp.MustCompile(`^(\d{12})\.dkr\.ecr(-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+)
+
+// Registry represents ECR registry
+type Registry struct {
+	ID     string `json:"ID"`
+	Region string `json:"Region"`
+}
+
+// ParseRegistryURL extracts registry ID and region from ECR registry URL
+// Both hostname (012345678910.dkr.ecr.us-east-1.amazonaws.com) and URL (https://012345678910.dkr.ecr.us-east-1.amazonaws.com/v2/) are accepted
+func ParseRegistryURL(s string) (*Registry, error) {
+	host := s
+
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+
+	m := registryHostRegexp.FindStringSubmatch(strings.ToLower(host))
+	if m == nil {
+		return nil, errors.Errorf("%q is not ECR registry", s)
+	}
+
+	return &Registry{
+		ID:     m[1],
+		Region: m[3],
+	}, nil
+}
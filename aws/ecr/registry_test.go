@@ -0,0 +1,63 @@
+package ecr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRegistryURL(t *testing.T) {
+	testcases := []struct {
+		s        string
+		expected *Registry
+	}{
+		{
+			s:        "012345678910.dkr.ecr.us-east-1.amazonaws.com",
+			expected: &Registry{ID: "012345678910", Region: "us-east-1"},
+		},
+		{
+			s:        "https://012345678910.dkr.ecr.ap-northeast-1.amazonaws.com",
+			expected: &Registry{ID: "012345678910", Region: "ap-northeast-1"},
+		},
+		{
+			s:        "https://012345678910.dkr.ecr.cn-north-1.amazonaws.com.cn:443/v2/",
+			expected: &Registry{ID: "012345678910", Region: "cn-north-1"},
+		},
+		{
+			s:        "012345678910.dkr.ecr-fips.us-gov-west-1.amazonaws.com",
+			expected: &Registry{ID: "012345678910", Region: "us-gov-west-1"},
+		},
+		{
+			s:        "https://index.docker.io/v1/",
+			expected: nil,
+		},
+		{
+			s:        "12345.dkr.ecr.us-east-1.amazonaws.com",
+			expected: nil,
+		},
+		{
+			s:        "012345678910.dkr.ecr.us-east-1.amazonaws.com.example.com",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := ParseRegistryURL(tc.s)
+
+		if tc.expected == nil {
+			if err == nil {
+				t.Errorf("error should be raised for %q", tc.s)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("error should not be raised for %q: %s", tc.s, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("registry does not match for %q. expected: %#v, got: %#v", tc.s, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package ecr
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SortByPushed sorts images by PushedAt
+	SortByPushed = "pushed"
+	// SortBySize sorts images by SizeInBytes
+	SortBySize = "size"
+	// SortByDigest sorts images by Digest
+	SortByDigest = "digest"
+	// SortByTag sorts images by the smallest tag. Untagged images come last
+	SortByTag = "tag"
+)
+
+var (
+	// ImageSortKeys is the list of supported sort keys of images
+	ImageSortKeys = []string{
+		SortByPushed,
+		SortBySize,
+		SortByDigest,
+		SortByTag,
+	}
+)
+
+// SortImages sorts images by the given key in ascending order, or descending order if reverse is true
+func SortImages(images []*Image, key string, reverse bool) error {
+	var less func(a, b *Image) bool
+
+	switch key {
+	case SortByPushed:
+		less = func(a, b *Image) bool {
+			return a.PushedAt.Before(b.PushedAt)
+		}
+	case SortBySize:
+		less = func(a, b *Image) bool {
+			return a.SizeInBytes < b.SizeInBytes
+		}
+	case SortByDigest:
+		less = func(a, b *Image) bool {
+			return a.Digest < b.Digest
+		}
+	case SortByTag:
+		less = func(a, b *Image) bool {
+			at, bt := minTag(a.Tags), minTag(b.Tags)
+
+			if at == "" || bt == "" {
+				return at != "" && bt == ""
+			}
+
+			return at < bt
+		}
+	default:
+		return errors.Errorf("sort key must be one of %s. got: %q", strings.Join(ImageSortKeys, ", "), key)
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		if reverse {
+			return less(images[j], images[i])
+		}
+
+		return less(images[i], images[j])
+	})
+
+	return nil
+}
+
+func minTag(tags []string) string {
+	min := ""
+
+	for _, tag := range tags {
+		if min == "" || tag < min {
+			min = tag
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,90 @@
+package ecr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortImages(t *testing.T) {
+	pushedAt := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	newImages := func() []*Image {
+		return []*Image{
+			&Image{
+				Digest:      "sha256:b",
+				Tags:        []string{"v2", "latest"},
+				SizeInBytes: 300,
+				PushedAt:    pushedAt.Add(2 * time.Hour),
+			},
+			&Image{
+				Digest:      "sha256:c",
+				Tags:        []string{},
+				SizeInBytes: 100,
+				PushedAt:    pushedAt,
+			},
+			&Image{
+				Digest:      "sha256:a",
+				Tags:        []string{"v1"},
+				SizeInBytes: 200,
+				PushedAt:    pushedAt.Add(1 * time.Hour),
+			},
+		}
+	}
+
+	testcases := []struct {
+		key      string
+		reverse  bool
+		expected []string
+	}{
+		{
+			key:      SortByPushed,
+			expected: []string{"sha256:c", "sha256:a", "sha256:b"},
+		},
+		{
+			key:      SortByPushed,
+			reverse:  true,
+			expected: []string{"sha256:b", "sha256:a", "sha256:c"},
+		},
+		{
+			key:      SortBySize,
+			expected: []string{"sha256:c", "sha256:a", "sha256:b"},
+		},
+		{
+			key:      SortByDigest,
+			expected: []string{"sha256:a", "sha256:b", "sha256:c"},
+		},
+		{
+			key:      SortByTag,
+			expected: []string{"sha256:b", "sha256:a", "sha256:c"},
+		},
+		{
+			key:      SortByTag,
+			reverse:  true,
+			expected: []string{"sha256:c", "sha256:a", "sha256:b"},
+		},
+	}
+
+	for _, tc := range testcases {
+		images := newImages()
+
+		if err := SortImages(images, tc.key, tc.reverse); err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		got := []string{}
+
+		for _, image := range images {
+			got = append(got, image.Digest)
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("order by %s (reverse: %t) does not match. expected: %q, got: %q", tc.key, tc.reverse, tc.expected, got)
+		}
+	}
+
+	if err := SortImages(newImages(), "unknown", false); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/policy"
+	"github.com/dtan4/ecrcli/spec"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var applyOpts = struct {
+	file        string
+	allowCreate bool
+	allowDelete bool
+	allowEmpty  bool
+	yes         bool
+}{}
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply -f FILE",
+	Short: "Make repositories match the spec",
+	Long: `Make repositories match the spec
+
+The changes printed by plan are applied after confirmation.
+Policy updates are always applied, while repository creations and deletions are skipped
+unless --allow-create and --allow-delete are given respectively.
+Repositories which contain images cannot be deleted.`,
+	RunE: doApply,
+}
+
+func doApply(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if applyOpts.file == "-" && !applyOpts.yes {
+		return errors.New("--yes must be given to read spec from stdin")
+	}
+
+	s, err := loadSpec(applyOpts.file, applyOpts.allowEmpty)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	planned, err := planChanges(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	changes := []*spec.Change{}
+	skipped := []*spec.Change{}
+
+	for _, c := range planned {
+		if (c.Action == spec.ActionCreate && !applyOpts.allowCreate) || (c.Action == spec.ActionDelete && !applyOpts.allowDelete) {
+			skipped = append(skipped, c)
+			continue
+		}
+
+		changes = append(changes, c)
+	}
+
+	printPlan(changes)
+
+	if len(skipped) > 0 {
+		fmt.Println()
+
+		for _, c := range skipped {
+			fmt.Printf("skipped: %s (pass --allow-%s)\n", c, c.Action)
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !applyOpts.yes {
+		fmt.Println()
+
+		ok, err := confirm("Apply these changes?")
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Println("canceled")
+			return nil
+		}
+	}
+
+	for _, c := range changes {
+		switch c.Action {
+		case spec.ActionCreate:
+			if _, err := aws.ECR.CreateRepositoryWithContext(ctx, c.Repository); err != nil {
+				return errors.Wrapf(err, "failed to create %s", c.Repository)
+			}
+
+			if c.Policy != nil {
+				if err := setPolicy(ctx, c); err != nil {
+					return err
+				}
+			}
+		case spec.ActionUpdate:
+			if c.Policy == nil {
+				if err := aws.ECR.DeleteRepositoryPolicyWithContext(ctx, c.Repository); err != nil {
+					return errors.Wrapf(err, "failed to delete policy of %s", c.Repository)
+				}
+			} else if err := setPolicy(ctx, c); err != nil {
+				return err
+			}
+		case spec.ActionDelete:
+			if err := aws.ECR.DeleteRepositoryWithContext(ctx, c.Repository, false); err != nil {
+				return errors.Wrapf(err, "failed to delete %s", c.Repository)
+			}
+		}
+
+		fmt.Printf("done: %s\n", c)
+	}
+
+	return nil
+}
+
+// setPolicy sets the desired policy of change
+func setPolicy(ctx context.Context, c *spec.Change) error {
+	formatted, err := policy.Format(c.Policy)
+	if err != nil {
+		return err
+	}
+
+	if err := aws.ECR.SetRepositoryPolicyWithContext(ctx, c.Repository, formatted); err != nil {
+		return errors.Wrapf(err, "failed to set policy of %s", c.Repository)
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringVarP(&applyOpts.file, "file", "f", "", `Spec file ("-" for stdin)`)
+	applyCmd.Flags().BoolVar(&applyOpts.allowCreate, "allow-create", false, "Create repositories which are not found")
+	applyCmd.Flags().BoolVar(&applyOpts.allowDelete, "allow-delete", false, "Delete repositories which are not in the spec")
+	applyCmd.Flags().BoolVar(&applyOpts.allowEmpty, "allow-empty", false, "Accept spec without repositories")
+	applyCmd.Flags().BoolVarP(&applyOpts.yes, "yes", "y", false, "Skip confirmation")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// credentialHelperName is the executable name Docker looks up for credsStore / credHelpers "ecrcli"
+	credentialHelperName = "docker-credential-ecrcli"
+)
+
+// errCredentialsNotFound is the message Docker treats as missing credentials
+var errCredentialsNotFound = errors.New("credentials not found in native keychain")
+
+// credentialHelperCredentials represents the credentials in Docker credential helper protocol
+type credentialHelperCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// credentialHelperCmd represents the credential-helper command
+var credentialHelperCmd = &cobra.Command{
+	Use:   "credential-helper get|store|erase|list",
+	Short: "Docker credential helper for ECR",
+	Long: `Docker credential helper for ECR
+
+Implements Docker credential helper protocol. Region is taken from the hostname of the given server URL.
+store and erase are no-op because credentials are always retrieved from ECR.
+
+ecrcli behaves as this command also when invoked as ` + credentialHelperName + `.
+To use it from Docker, link ecrcli to ` + credentialHelperName + ` in $PATH and add the following to ~/.docker/config.json:
+
+  {"credHelpers": {"012345678910.dkr.ecr.us-east-1.amazonaws.com": "ecrcli"}}`,
+	RunE:          doCredentialHelper,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+}
+
+func doCredentialHelper(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("action must be given: get, store, erase or list")
+	}
+
+	switch args[0] {
+	case "get":
+		return credentialHelperGet()
+	case "store", "erase":
+		// Read and discard input so that Docker does not get EPIPE
+		if _, err := ioutil.ReadAll(os.Stdin); err != nil {
+			return errors.Wrap(err, "failed to read input")
+		}
+
+		return nil
+	case "list":
+		fmt.Println("{}")
+
+		return nil
+	default:
+		return errors.Errorf("unknown action %q. action must be get, store, erase or list", args[0])
+	}
+}
+
+func credentialHelperGet() error {
+	input, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return errors.Wrap(err, "failed to read server URL")
+	}
+
+	serverURL := strings.TrimSpace(string(input))
+
+	registry, err := ecr.ParseRegistryURL(serverURL)
+	if err != nil {
+		return errCredentialsNotFound
+	}
+
+	if err := aws.Initialize(registry.Region, rootOpts.timeout); err != nil {
+		return errors.Wrap(err, "failed to initialize AWS API clients")
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	credentials, err := getCredentials(ctx, []string{registry.ID}, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve credentials for %s", serverURL)
+	}
+
+	creds := credentials[0]
+
+	if err := json.NewEncoder(os.Stdout).Encode(&credentialHelperCredentials{
+		ServerURL: serverURL,
+		Username:  creds.Username,
+		Secret:    creds.Password,
+	}); err != nil {
+		return errors.Wrap(err, "failed to encode credentials")
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(credentialHelperCmd)
+}
@@ -2,30 +2,191 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
-	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/docker"
+	"github.com/dtan4/ecrcli/kubernetes"
+	"github.com/dtan4/ecrcli/login"
+	"github.com/dtan4/ecrcli/renderer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+var getLoginOpts = struct {
+	tool              string
+	authFile          bool
+	writeDockerConfig bool
+	passwordStdin     bool
+	noCache           bool
+	registryIDs       []string
+	kubernetesSecret  string
+	namespace         string
+}{}
+
 // getLoginCmd represents the get-login command
 var getLoginCmd = &cobra.Command{
 	Use:   "get-login",
 	Short: "Print ECR login command",
-	RunE:  doGetLogin,
+	Long: `Print ECR login command
+
+Login commands for other tools can be printed with --for. Except for docker, commands pass the password via stdin,
+so evaluate them with shell:
+
+  eval "$(ecrcli get-login --for helm)"
+
+--auth-file prints auth file of the tool instead, e.g. containers auth.json for podman, buildah and skopeo,
+and registry config of containerd for crictl.
+
+Printed docker command contains the password in its arguments, so it remains in shell history and process list.
+Use --write-docker-config to write credentials into Docker config ($DOCKER_CONFIG/config.json or ~/.docker/config.json) directly,
+or --password-stdin to print only the password:
+
+  ecrcli get-login --password-stdin | docker login -u AWS --password-stdin https://012345678910.dkr.ecr.us-east-1.amazonaws.com
+
+--write-docker-config fails if Docker config has credsStore or credHelpers for the registry,
+because Docker ignores auths then. Use --password-stdin with docker login in that case.
+
+Use --kubernetes-secret to print Kubernetes Secret manifest for imagePullSecrets, which contains all the given registries:
+
+  ecrcli get-login --kubernetes-secret ecr --namespace default --registry-id 012345678910 --registry-id 109876543210 | kubectl apply -f -
+
+Credentials are cached in the user cache directory ($XDG_CACHE_HOME/ecrcli or ~/.cache/ecrcli) until shortly before they expire.`,
+	RunE: doGetLogin,
 }
 
 func doGetLogin(cmd *cobra.Command, args []string) error {
-	loginCmd, err := aws.ECR.GetLogin()
+	modes := 0
+
+	for _, enabled := range []bool{getLoginOpts.authFile, getLoginOpts.writeDockerConfig, getLoginOpts.passwordStdin, getLoginOpts.kubernetesSecret != ""} {
+		if enabled {
+			modes++
+		}
+	}
+
+	if modes > 1 {
+		return errors.New("only one of --auth-file, --write-docker-config, --password-stdin and --kubernetes-secret can be specified")
+	}
+
+	// only Kubernetes Secret is rendered
+	if getLoginOpts.kubernetesSecret == "" {
+		if err := checkNotRendered(cmd); err != nil {
+			return err
+		}
+	}
+
+	if getLoginOpts.writeDockerConfig && getLoginOpts.tool != login.ToolDocker {
+		return errors.New("--write-docker-config can be used only for docker")
+	}
+
+	if getLoginOpts.passwordStdin && len(getLoginOpts.registryIDs) > 1 {
+		return errors.New("--password-stdin cannot be used with multiple --registry-id")
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	credentials, err := getCredentials(ctx, getLoginOpts.registryIDs, getLoginOpts.noCache)
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve docker login command")
+		return errors.Wrap(err, "failed to retrieve credentials")
+	}
+
+	if getLoginOpts.passwordStdin {
+		fmt.Println(credentials[0].Password)
+
+		return nil
 	}
 
-	fmt.Println(loginCmd)
+	if getLoginOpts.kubernetesSecret != "" {
+		return renderKubernetesSecret(credentials)
+	}
+
+	if getLoginOpts.authFile {
+		data, err := login.AuthFile(getLoginOpts.tool, credentials)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(data))
+
+		return nil
+	}
+
+	if !getLoginOpts.writeDockerConfig {
+		cmds, err := login.Commands(getLoginOpts.tool, credentials)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cmds {
+			fmt.Println(c)
+		}
+
+		return nil
+	}
+
+	dir, err := docker.ConfigDir()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve Docker config directory")
+	}
+
+	for _, creds := range credentials {
+		path, err := docker.WriteAuth(dir, creds.ProxyEndpoint, creds.Username, creds.Password)
+		if err != nil {
+			return errors.Wrap(err, "failed to write Docker config")
+		}
+
+		fmt.Printf("credentials for %s written to %s\n", creds.ProxyEndpoint, path)
+	}
 
 	return nil
 }
 
+// renderKubernetesSecret prints Secret manifest containing the given credentials, in YAML unless --output json is given
+func renderKubernetesSecret(credentials []*ecr.Credentials) error {
+	auths := []*docker.Auth{}
+
+	for _, creds := range credentials {
+		auths = append(auths, &docker.Auth{
+			Server:   creds.ProxyEndpoint,
+			Username: creds.Username,
+			Password: creds.Password,
+		})
+	}
+
+	config, err := docker.ConfigJSON(auths)
+	if err != nil {
+		return err
+	}
+
+	output := rootOpts.output
+	if output == renderer.OutputTable || output == renderer.OutputWide {
+		output = renderer.OutputYAML
+	}
+
+	r, err := renderer.New(os.Stdout, &renderer.Options{
+		Output: output,
+		Format: rootOpts.format,
+		Query:  rootOpts.query,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.Render(kubernetes.NewDockerConfigSecret(getLoginOpts.kubernetesSecret, getLoginOpts.namespace, config), nil)
+}
+
 func init() {
 	RootCmd.AddCommand(getLoginCmd)
+
+	getLoginCmd.Flags().StringVar(&getLoginOpts.tool, "for", login.ToolDocker, "Tool to log in with ("+strings.Join(login.Tools, "|")+")")
+	getLoginCmd.Flags().BoolVar(&getLoginOpts.authFile, "auth-file", false, "Print auth file of the tool selected by --for instead of login commands")
+
+	getLoginCmd.Flags().BoolVar(&getLoginOpts.writeDockerConfig, "write-docker-config", false, "Write credentials into Docker config instead of printing login command")
+	getLoginCmd.Flags().BoolVar(&getLoginOpts.noCache, "no-cache", false, "Always retrieve new credentials instead of using cached ones")
+	getLoginCmd.Flags().BoolVar(&getLoginOpts.passwordStdin, "password-stdin", false, "Print only the password, to be passed to docker login --password-stdin")
+	getLoginCmd.Flags().StringVar(&getLoginOpts.kubernetesSecret, "kubernetes-secret", "", "Print Kubernetes Secret manifest of type kubernetes.io/dockerconfigjson with the given name, for imagePullSecrets")
+	getLoginCmd.Flags().StringVar(&getLoginOpts.namespace, "namespace", "", "Namespace of Kubernetes Secret. Omitted if empty")
+	getLoginCmd.Flags().StringSliceVar(&getLoginOpts.registryIDs, "registry-id", []string{}, "AWS account ID of the registry to log in to. Can be specified multiple times. Default is the registry of the caller")
 }
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/renderer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	imageDeleteFailureHeader = []string{
+		"IMAGE",
+		"CODE",
+		"REASON",
+	}
+)
+
+// imageDeleteCmd represents the imageDelete command
+var imageDeleteCmd = &cobra.Command{
+	Use:   "delete REPO TAG_OR_DIGEST...",
+	Short: "Delete images",
+	Long: `Delete images by tags and/or digests
+
+Arguments starting with "sha256:" are treated as image digests, otherwise as image tags.
+Exits with non-zero status if any image failed to be deleted.`,
+	RunE: doImageDelete,
+}
+
+func doImageDelete(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return errors.New("repository name and at least one tag or digest must be given")
+	}
+	repo := args[0]
+
+	imageIDs := []*ecr.ImageID{}
+
+	for _, arg := range args[1:] {
+		imageIDs = append(imageIDs, ecr.ParseImageID(arg))
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	return deleteImages(ctx, r, repo, imageIDs)
+}
+
+// deleteImages deletes images and reports the result
+// If deletion fails halfway, images deleted so far are reported before returning the error
+func deleteImages(ctx context.Context, r *renderer.Renderer, repo string, imageIDs []*ecr.ImageID) error {
+	result, err := aws.ECR.DeleteImagesWithContext(ctx, repo, imageIDs)
+	if err != nil {
+		if result != nil && (len(result.Deleted) > 0 || len(result.Failures) > 0) {
+			reportDeleteImagesResult(r, result)
+		}
+
+		return errors.Wrapf(err, "failed to delete images from %s", repo)
+	}
+
+	return reportDeleteImagesResult(r, result)
+}
+
+// reportDeleteImagesResult prints deleted images and failures,
+// and returns error if any image failed to be deleted
+func reportDeleteImagesResult(r *renderer.Renderer, result *ecr.DeleteImagesResult) error {
+	if r.Structured() {
+		if err := r.Render(result, nil); err != nil {
+			return err
+		}
+
+		if len(result.Failures) > 0 {
+			return errors.Errorf("failed to delete %d image(s)", len(result.Failures))
+		}
+
+		return nil
+	}
+
+	for _, id := range result.Deleted {
+		fmt.Printf("deleted: %s\n", id)
+	}
+
+	if len(result.Failures) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(imageDeleteFailureHeader, "\t"))
+
+	for _, failure := range result.Failures {
+		fmt.Fprintln(w, strings.Join([]string{
+			failure.ImageID.String(),
+			failure.Code,
+			failure.Reason,
+		}, "\t"))
+	}
+
+	w.Flush()
+
+	return errors.Errorf("failed to delete %d image(s)", len(result.Failures))
+}
+
+func init() {
+	imageCmd.AddCommand(imageDeleteCmd)
+}
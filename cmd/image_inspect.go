@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	imageInspectLayerHeader = []string{
+		"DIGEST",
+		"SIZE",
+		"MEDIATYPE",
+	}
+
+	imageInspectManifestHeader = []string{
+		"PLATFORM",
+		"DIGEST",
+		"SIZE",
+		"MEDIATYPE",
+	}
+)
+
+// imageInspectCmd represents the imageInspect command
+var imageInspectCmd = &cobra.Command{
+	Use:   "inspect REPO:TAG|REPO@DIGEST",
+	Short: "Print image manifest details",
+	RunE:  doImageInspect,
+}
+
+func doImageInspect(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("image must be given")
+	}
+
+	repo, ref, err := parseImageRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	body, err := aws.ECR.GetManifestWithContext(ctx, repo, ref)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch manifest of %s", args[0])
+	}
+
+	m, err := manifest.Parse([]byte(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse manifest of %s", args[0])
+	}
+
+	if r.Structured() {
+		return r.Render(m, nil)
+	}
+
+	fmt.Printf("Repository: %s\n", repo)
+	fmt.Printf("Reference:  %s\n", ref)
+	fmt.Printf("MediaType:  %s\n", m.MediaType)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if m.IsList() {
+		fmt.Println()
+		fmt.Fprintln(w, strings.Join(imageInspectManifestHeader, "\t"))
+
+		for _, d := range m.Manifests {
+			platform := "-"
+			if d.Platform != nil {
+				platform = d.Platform.String()
+			}
+
+			fmt.Fprintln(w, strings.Join([]string{
+				platform,
+				d.Digest,
+				formatSize(d.Size),
+				d.MediaType,
+			}, "\t"))
+		}
+
+		w.Flush()
+
+		return nil
+	}
+
+	fmt.Printf("Config:     %s\n", m.Config.Digest)
+	fmt.Printf("Size:       %s\n", formatSize(m.LayersSize()))
+	fmt.Println()
+
+	fmt.Fprintln(w, strings.Join(imageInspectLayerHeader, "\t"))
+
+	for _, layer := range m.Layers {
+		fmt.Fprintln(w, strings.Join([]string{
+			layer.Digest,
+			formatSize(layer.Size),
+			layer.MediaType,
+		}, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func init() {
+	imageCmd.AddCommand(imageInspectCmd)
+}
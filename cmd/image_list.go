@@ -1,21 +1,45 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
 	"strings"
-	"text/tabwriter"
+	"time"
 
 	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/renderer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+var imageListOpts = struct {
+	columns      []string
+	pushedAfter  string
+	pushedBefore string
+	reverse      bool
+	sort         string
+	tag          string
+	tagged       bool
+	untagged     bool
+}{}
+
 var (
-	imageListHeader = []string{
-		"DIGEST",
-		"PUSHEDAT",
-		"TAGS",
+	imageListColumns = []*renderer.Column{
+		&renderer.Column{
+			Header: "REPOSITORY",
+			Wide:   true,
+		},
+		&renderer.Column{
+			Header: "DIGEST",
+		},
+		&renderer.Column{
+			Header: "PUSHEDAT",
+		},
+		&renderer.Column{
+			Header: "SIZE",
+		},
+		&renderer.Column{
+			Header: "TAGS",
+		},
 	}
 )
 
@@ -32,27 +56,184 @@ func doImageList(cmd *cobra.Command, args []string) error {
 	}
 	repo := args[0]
 
-	images, err := aws.ECR.ListImages(repo)
+	if imageListOpts.reverse && imageListOpts.sort == "" {
+		return errors.New("--reverse requires --sort")
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	filter, err := newImageListFilter(time.Now())
 	if err != nil {
+		return err
+	}
+
+	opts, err := newListImagesOptions()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	if r.Structured() || imageListOpts.sort != "" {
+		images, err := aws.ECR.ListImagesWithContext(ctx, repo, opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch image list of %s", repo)
+		}
+
+		images = filterImages(images, filter)
+
+		if imageListOpts.sort != "" {
+			if err := ecr.SortImages(images, imageListOpts.sort, imageListOpts.reverse); err != nil {
+				return err
+			}
+		}
+
+		table := &renderer.Table{
+			Columns: imageListColumns,
+			Rows:    [][]string{},
+			Visible: imageListOpts.columns,
+		}
+
+		var total int64
+
+		for _, image := range images {
+			table.Rows = append(table.Rows, imageListRow(image))
+			total += image.SizeInBytes
+		}
+
+		table.Rows = append(table.Rows, imageListTotalRow(total))
+
+		return r.Render(images, table)
+	}
+
+	w, err := r.NewTableWriter(imageListColumns, imageListOpts.columns)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader()
+
+	var total int64
+
+	if err := aws.ECR.ListImagesPagesWithContext(ctx, repo, opts, func(images []*ecr.Image, lastPage bool) bool {
+		for _, image := range filterImages(images, filter) {
+			w.Write(imageListRow(image))
+
+			total += image.SizeInBytes
+		}
+
+		w.Flush()
+
+		return true
+	}); err != nil {
 		return errors.Wrapf(err, "failed to fetch image list of %s", repo)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, strings.Join(imageListHeader, "\t"))
+	w.Write(imageListTotalRow(total))
+
+	return w.Flush()
+}
+
+func newListImagesOptions() (*ecr.ListImagesOptions, error) {
+	if imageListOpts.tagged && imageListOpts.untagged {
+		return nil, errors.New("--tagged and --untagged cannot be given at the same time")
+	}
+
+	opts := &ecr.ListImagesOptions{
+		TagStatus:  ecr.TagStatusAny,
+		TagPattern: imageListOpts.tag,
+	}
+
+	switch {
+	case imageListOpts.tagged:
+		opts.TagStatus = ecr.TagStatusTagged
+	case imageListOpts.untagged:
+		opts.TagStatus = ecr.TagStatusUntagged
+	}
+
+	return opts, nil
+}
+
+// newImageListFilter returns the function which reports whether the image satisfies --pushed-after and --pushed-before
+func newImageListFilter(now time.Time) (func(image *ecr.Image) bool, error) {
+	var after, before time.Time
+
+	if imageListOpts.pushedAfter != "" {
+		t, err := parseTimeFlag(imageListOpts.pushedAfter, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid --pushed-after")
+		}
+
+		after = t
+	}
+
+	if imageListOpts.pushedBefore != "" {
+		t, err := parseTimeFlag(imageListOpts.pushedBefore, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid --pushed-before")
+		}
+
+		before = t
+	}
+
+	return func(image *ecr.Image) bool {
+		if !after.IsZero() && !image.PushedAt.After(after) {
+			return false
+		}
+
+		if !before.IsZero() && !image.PushedAt.Before(before) {
+			return false
+		}
+
+		return true
+	}, nil
+}
+
+func filterImages(images []*ecr.Image, filter func(image *ecr.Image) bool) []*ecr.Image {
+	filtered := []*ecr.Image{}
 
 	for _, image := range images {
-		fmt.Fprintln(w, strings.Join([]string{
-			image.Digest,
-			image.PushedAt.Local().String(),
-			strings.Join(image.Tags, ","),
-		}, "\t"))
+		if filter(image) {
+			filtered = append(filtered, image)
+		}
 	}
 
-	w.Flush()
+	return filtered
+}
 
-	return nil
+func imageListRow(image *ecr.Image) []string {
+	return []string{
+		image.Repository,
+		image.Digest,
+		formatTime(image.PushedAt),
+		formatSize(image.SizeInBytes),
+		strings.Join(image.Tags, ","),
+	}
+}
+
+func imageListTotalRow(total int64) []string {
+	return []string{
+		"",
+		"TOTAL",
+		"",
+		formatSize(total),
+		"",
+	}
 }
 
 func init() {
 	imageCmd.AddCommand(imageListCmd)
+
+	imageListCmd.Flags().StringSliceVar(&imageListOpts.columns, "columns", []string{}, "Comma-separated columns to print (repository, digest, pushedat, size, tags)")
+	imageListCmd.Flags().StringVar(&imageListOpts.pushedAfter, "pushed-after", "", "Show images pushed after the time (RFC3339 timestamp, date or duration like 72h)")
+	imageListCmd.Flags().StringVar(&imageListOpts.pushedBefore, "pushed-before", "", "Show images pushed before the time (RFC3339 timestamp, date or duration like 72h)")
+	imageListCmd.Flags().BoolVar(&imageListOpts.reverse, "reverse", false, "Sort in descending order. Requires --sort")
+	imageListCmd.Flags().StringVar(&imageListOpts.tag, "tag", "", "Show images which have a tag matching the glob pattern (e.g. 'release-*'), or regular expression enclosed in slashes (e.g. '/^v[0-9]+$/')")
+	imageListCmd.Flags().BoolVar(&imageListOpts.tagged, "tagged", false, "Show tagged images only")
+	imageListCmd.Flags().BoolVar(&imageListOpts.untagged, "untagged", false, "Show untagged images only")
+	imageListCmd.Flags().StringVar(&imageListOpts.sort, "sort", "", "Sort images by key ("+strings.Join(ecr.ImageSortKeys, "|")+")")
 }
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/prune"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var imagePruneOpts = struct {
+	keepLast  int
+	olderThan time.Duration
+	untagged  bool
+	include   string
+	exclude   string
+	execute   bool
+}{}
+
+var (
+	imagePruneHeader = []string{
+		"DIGEST",
+		"PUSHEDAT",
+		"SIZE",
+		"TAGS",
+	}
+)
+
+// imagePruneCmd represents the imagePrune command
+var imagePruneCmd = &cobra.Command{
+	Use:   "prune REPO",
+	Short: "Delete images matching the given rules",
+	Long: `Delete images matching the given rules
+
+Images are selected only if they satisfy all the given rules.
+By default, only the plan of deletion is printed. Pass --execute to actually delete images.`,
+	RunE: doImagePrune,
+}
+
+func doImagePrune(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	repo := args[0]
+
+	rule, err := newPruneRule()
+	if err != nil {
+		return err
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	images, err := aws.ECR.ListImagesWithContext(ctx, repo, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch image list of %s", repo)
+	}
+
+	selected := prune.Select(images, rule, time.Now())
+
+	if r.Structured() {
+		if !imagePruneOpts.execute {
+			return r.Render(selected, nil)
+		}
+	} else {
+		printPrunePlan(selected)
+	}
+
+	if len(selected) == 0 || !imagePruneOpts.execute {
+		return nil
+	}
+
+	imageIDs := []*ecr.ImageID{}
+
+	for _, image := range selected {
+		imageIDs = append(imageIDs, &ecr.ImageID{
+			Digest: image.Digest,
+		})
+	}
+
+	return deleteImages(ctx, r, repo, imageIDs)
+}
+
+func printPrunePlan(selected []*ecr.Image) {
+	if len(selected) == 0 {
+		fmt.Println("no images to be deleted")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(imagePruneHeader, "\t"))
+
+	for _, image := range selected {
+		fmt.Fprintln(w, strings.Join([]string{
+			image.Digest,
+			formatTime(image.PushedAt),
+			formatSize(image.SizeInBytes),
+			strings.Join(image.Tags, ","),
+		}, "\t"))
+	}
+
+	w.Flush()
+
+	fmt.Printf("\n%d image(s), %s in total will be deleted\n", len(selected), formatSize(prune.TotalSize(selected)))
+
+	if !imagePruneOpts.execute {
+		fmt.Println("this is dry run. pass --execute to delete images")
+	}
+}
+
+func newPruneRule() (*prune.Rule, error) {
+	if imagePruneOpts.keepLast == 0 && imagePruneOpts.olderThan == 0 && !imagePruneOpts.untagged && imagePruneOpts.include == "" && imagePruneOpts.exclude == "" {
+		return nil, errors.New("at least one rule must be given")
+	}
+
+	if imagePruneOpts.keepLast < 0 {
+		return nil, errors.New("--keep-last must not be negative")
+	}
+
+	rule := &prune.Rule{
+		KeepLast:     imagePruneOpts.keepLast,
+		OlderThan:    imagePruneOpts.olderThan,
+		UntaggedOnly: imagePruneOpts.untagged,
+	}
+
+	if imagePruneOpts.include != "" {
+		re, err := regexp.Compile(imagePruneOpts.include)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid --include pattern")
+		}
+
+		rule.Include = re
+	}
+
+	if imagePruneOpts.exclude != "" {
+		re, err := regexp.Compile(imagePruneOpts.exclude)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid --exclude pattern")
+		}
+
+		rule.Exclude = re
+	}
+
+	return rule, nil
+}
+
+func init() {
+	imageCmd.AddCommand(imagePruneCmd)
+
+	imagePruneCmd.Flags().IntVar(&imagePruneOpts.keepLast, "keep-last", 0, "Number of the newest images to keep among images matching --untagged, --include and --exclude")
+	imagePruneCmd.Flags().DurationVar(&imagePruneOpts.olderThan, "older-than", 0, "Select images pushed before the duration ago (e.g. 720h)")
+	imagePruneCmd.Flags().BoolVar(&imagePruneOpts.untagged, "untagged", false, "Select untagged images only")
+	imagePruneCmd.Flags().StringVar(&imagePruneOpts.include, "include", "", "Select images which have a tag matching the regular expression")
+	imagePruneCmd.Flags().StringVar(&imagePruneOpts.exclude, "exclude", "", "Keep images which have a tag matching the regular expression")
+	imagePruneCmd.Flags().BoolVar(&imagePruneOpts.execute, "execute", false, "Actually delete images")
+}
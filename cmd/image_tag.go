@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var imageTagOpts = struct {
+	force bool
+}{}
+
+// imageTagCmd represents the imageTag command
+var imageTagCmd = &cobra.Command{
+	Use:   "tag REPO SRC NEWTAG",
+	Short: "Add new tag to image without pulling",
+	Long: `Add new tag to image without pulling
+
+SRC is the tag or digest of the source image.
+If NEWTAG already points to another image, --force is required to move it.`,
+	RunE: doImageTag,
+}
+
+func doImageTag(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if len(args) != 3 {
+		return errors.New("repository name, source tag or digest and new tag must be given")
+	}
+	repo, src, newTag := args[0], args[1], args[2]
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	if !imageTagOpts.force {
+		srcImage, err := aws.ECR.GetImageWithContext(ctx, repo, src)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch image %s", src)
+		}
+
+		if srcImage == nil {
+			return errors.Errorf("image %s not found in %s", src, repo)
+		}
+
+		current, err := aws.ECR.GetImageWithContext(ctx, repo, newTag)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch image %s", newTag)
+		}
+
+		if current != nil && current.Digest != srcImage.Digest {
+			return errors.Errorf("tag %s already points to %s. pass --force to move it", newTag, current.Digest)
+		}
+	}
+
+	if err := aws.ECR.TagImageWithContext(ctx, repo, src, newTag); err != nil {
+		if errors.Cause(err) == ecr.ErrImageAlreadyExists {
+			fmt.Printf("%s is already tagged as %s\n", src, newTag)
+			return nil
+		}
+
+		return errors.Wrapf(err, "failed to tag %s as %s", src, newTag)
+	}
+
+	fmt.Printf("tagged: %s -> %s\n", src, newTag)
+
+	return nil
+}
+
+func init() {
+	imageCmd.AddCommand(imageTagCmd)
+
+	imageTagCmd.Flags().BoolVar(&imageTagOpts.force, "force", false, "Move the tag if it already points to another image")
+}
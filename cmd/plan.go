@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/policy"
+	"github.com/dtan4/ecrcli/spec"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var planOpts = struct {
+	file       string
+	allowEmpty bool
+}{}
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan -f FILE",
+	Short: "Print changes to make repositories match the spec",
+	Long: `Print changes to make repositories match the spec
+
+The spec is a YAML file listing all repositories and their policies:
+
+  repositories:
+    - name: foo
+      policy:
+        Version: "2008-10-17"
+        Statement:
+          - Sid: pull
+            Effect: Allow
+            Principal:
+              AWS: arn:aws:iam::109876543210:root
+            Action:
+              - ecr:BatchGetImage
+              - ecr:GetDownloadUrlForLayer
+    - name: bar
+
+Policy can also be written as JSON string. Repositories without policy must have no policy.
+Repositories not in the spec are planned to be deleted.
+The repositories key is required, and spec without repositories is rejected unless --allow-empty is given.`,
+	RunE: doPlan,
+}
+
+func doPlan(cmd *cobra.Command, args []string) error {
+	s, err := loadSpec(planOpts.file, planOpts.allowEmpty)
+	if err != nil {
+		return err
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	changes, err := planChanges(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if r.Structured() {
+		return r.Render(changes, nil)
+	}
+
+	printPlan(changes)
+
+	return nil
+}
+
+// loadSpec reads the spec from file, or stdin if file is "-"
+// Spec without repositories is rejected unless allowEmpty is true, as it plans to delete all repositories
+func loadSpec(file string, allowEmpty bool) (*spec.Spec, error) {
+	if file == "" {
+		return nil, errors.New("spec file must be given with -f")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read spec")
+	}
+
+	s, err := spec.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Repositories) == 0 && !allowEmpty {
+		return nil, errors.New("spec has no repositories. pass --allow-empty to plan deletion of all repositories")
+	}
+
+	return s, nil
+}
+
+// planChanges fetches the current repositories and their policies, and returns changes to match the spec
+func planChanges(ctx context.Context, s *spec.Spec) ([]*spec.Change, error) {
+	current, err := fetchState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec.Plan(s, current), nil
+}
+
+// fetchState fetches the current repositories and their policies
+func fetchState(ctx context.Context) (spec.State, error) {
+	repos, err := aws.ECR.ListRepositoriesWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch repository list")
+	}
+
+	current := spec.State{}
+
+	for _, repo := range repos {
+		text, err := aws.ECR.GetRepositoryPolicyWithContext(ctx, repo.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch policy of %s", repo.Name)
+		}
+
+		var doc map[string]interface{}
+
+		if text != "" {
+			doc, err = policy.Parse([]byte(text))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse current policy of %s", repo.Name)
+			}
+		}
+
+		current[repo.Name] = doc
+	}
+
+	return current, nil
+}
+
+// printPlan prints changes with their policy differences
+func printPlan(changes []*spec.Change) {
+	if len(changes) == 0 {
+		fmt.Println("no changes")
+		return
+	}
+
+	counts := map[string]int{}
+
+	for _, c := range changes {
+		fmt.Println(c)
+
+		for _, line := range c.Diff {
+			fmt.Printf("    %s\n", line)
+		}
+
+		counts[c.Action]++
+	}
+
+	fmt.Printf("\nplan: %d to create, %d to update, %d to delete\n", counts[spec.ActionCreate], counts[spec.ActionUpdate], counts[spec.ActionDelete])
+}
+
+func init() {
+	RootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().StringVarP(&planOpts.file, "file", "f", "", `Spec file ("-" for stdin)`)
+	planCmd.Flags().BoolVar(&planOpts.allowEmpty, "allow-empty", false, "Accept spec without repositories")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// repoCreateCmd represents the repoCreate command
+var repoCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create repository",
+	RunE:  doRepoCreate,
+}
+
+func doRepoCreate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	name := args[0]
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	repo, err := aws.ECR.CreateRepositoryWithContext(ctx, name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create repository %s", name)
+	}
+
+	if r.Structured() {
+		return r.Render(repo, nil)
+	}
+
+	fmt.Printf("created: %s\n", repo.URI)
+
+	return nil
+}
+
+func init() {
+	repoCmd.AddCommand(repoCreateCmd)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var repoDeleteOpts = struct {
+	force bool
+	yes   bool
+}{}
+
+// repoDeleteCmd represents the repoDelete command
+var repoDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete repository",
+	Long: `Delete repository
+
+Repositories containing images are refused to be deleted unless --force is given.`,
+	RunE: doRepoDelete,
+}
+
+func doRepoDelete(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	name := args[0]
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	images, err := aws.ECR.ListImagesWithContext(ctx, name, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch image list of %s", name)
+	}
+
+	if len(images) > 0 && !repoDeleteOpts.force {
+		return errors.Errorf("repository %s contains %d image(s). pass --force to delete it with all images", name, len(images))
+	}
+
+	if !repoDeleteOpts.yes {
+		fmt.Printf("repository %s will be deleted. %d image(s) will be lost\n", name, len(images))
+
+		ok, err := confirm("Are you sure?")
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Println("canceled")
+			return nil
+		}
+	}
+
+	if err := aws.ECR.DeleteRepositoryWithContext(ctx, name, repoDeleteOpts.force); err != nil {
+		return errors.Wrapf(err, "failed to delete repository %s", name)
+	}
+
+	fmt.Printf("deleted: %s\n", name)
+
+	return nil
+}
+
+func init() {
+	repoCmd.AddCommand(repoDeleteCmd)
+
+	repoDeleteCmd.Flags().BoolVar(&repoDeleteOpts.force, "force", false, "Delete repository even if it contains images")
+	repoDeleteCmd.Flags().BoolVarP(&repoDeleteOpts.yes, "yes", "y", false, "Skip confirmation")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/dtan4/ecrcli/spec"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var repoExportOpts = struct {
+	file string
+}{}
+
+// repoExportCmd represents the repoExport command
+var repoExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export repositories and their policies as spec",
+	Long: `Export repositories and their policies as spec
+
+The spec is printed in YAML which can be passed to plan and apply.
+Lifecycle policies are not exported yet because the AWS SDK in use does not support them.`,
+	RunE: doRepoExport,
+}
+
+func doRepoExport(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	state, err := fetchState(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := spec.FromState(state).Marshal()
+	if err != nil {
+		return err
+	}
+
+	if repoExportOpts.file == "" || repoExportOpts.file == "-" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := ioutil.WriteFile(repoExportOpts.file, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", repoExportOpts.file)
+	}
+
+	fmt.Printf("exported %d repositories to %s\n", len(state), repoExportOpts.file)
+
+	return nil
+}
+
+func init() {
+	repoCmd.AddCommand(repoExportCmd)
+
+	repoExportCmd.Flags().StringVarP(&repoExportOpts.file, "file", "f", "", "File to write spec. Default is stdout")
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// repoLifecycleCmd represents the repoLifecycle command
+var repoLifecycleCmd = &cobra.Command{
+	Use:   "lifecycle <subcommand>",
+	Short: "Repository lifecycle policy related commands",
+}
+
+func init() {
+	repoCmd.AddCommand(repoLifecycleCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/lifecycle"
+	"github.com/dtan4/ecrcli/prune"
+	"github.com/dtan4/ecrcli/renderer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var repoLifecycleSimulateOpts = struct {
+	policy string
+}{}
+
+var (
+	repoLifecycleSimulateColumns = []*renderer.Column{
+		&renderer.Column{
+			Header: "RULE",
+		},
+		&renderer.Column{
+			Header: "DIGEST",
+		},
+		&renderer.Column{
+			Header: "PUSHEDAT",
+		},
+		&renderer.Column{
+			Header: "SIZE",
+		},
+		&renderer.Column{
+			Header: "TAGS",
+		},
+		&renderer.Column{
+			Header: "DESCRIPTION",
+			Wide:   true,
+		},
+	}
+)
+
+// repoLifecycleSimulateCmd represents the repoLifecycleSimulate command
+var repoLifecycleSimulateCmd = &cobra.Command{
+	Use:   "simulate REPO",
+	Short: "Print images which the lifecycle policy would expire",
+	Long: `Print images which the lifecycle policy would expire
+
+The lifecycle policy is evaluated locally against the current images, without putting it on the repository.
+Rules are evaluated in order of rulePriority. An image matching tagStatus and tagPrefixList of a rule
+is never expired by rules with lower priority, even if the rule itself does not expire it.`,
+	RunE: doRepoLifecycleSimulate,
+}
+
+func doRepoLifecycleSimulate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	repo := args[0]
+
+	if repoLifecycleSimulateOpts.policy == "" {
+		return errors.New("--policy must be given")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if repoLifecycleSimulateOpts.policy == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(repoLifecycleSimulateOpts.policy)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to read lifecycle policy")
+	}
+
+	policy, err := lifecycle.Parse(data)
+	if err != nil {
+		return errors.Wrap(err, "invalid lifecycle policy")
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	images, err := aws.ECR.ListImagesWithContext(ctx, repo, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch image list of %s", repo)
+	}
+
+	results := lifecycle.Evaluate(policy, images, time.Now())
+
+	if r.Structured() {
+		return r.Render(results, nil)
+	}
+
+	expired := []*ecr.Image{}
+	rows := [][]string{}
+
+	for _, result := range results {
+		for _, image := range result.Expired {
+			rows = append(rows, []string{
+				strconv.Itoa(result.Rule.RulePriority),
+				image.Digest,
+				formatTime(image.PushedAt),
+				formatSize(image.SizeInBytes),
+				strings.Join(image.Tags, ","),
+				result.Rule.Description,
+			})
+		}
+
+		expired = append(expired, result.Expired...)
+	}
+
+	if len(expired) == 0 {
+		fmt.Println("no images would be expired")
+		return nil
+	}
+
+	if err := r.Render(nil, &renderer.Table{
+		Columns: repoLifecycleSimulateColumns,
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d of %d image(s), %s in total would be expired\n", len(expired), len(images), formatSize(prune.TotalSize(expired)))
+
+	return nil
+}
+
+func init() {
+	repoLifecycleCmd.AddCommand(repoLifecycleSimulateCmd)
+
+	repoLifecycleSimulateCmd.Flags().StringVar(&repoLifecycleSimulateOpts.policy, "policy", "", `Lifecycle policy JSON file ("-" for stdin)`)
+}
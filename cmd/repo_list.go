@@ -1,21 +1,28 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"strings"
-	"text/tabwriter"
-
 	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/renderer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
-	repoListHeader = []string{
-		"NAME",
-		"URI",
-		"CREATEDAT",
+	repoListColumns = []*renderer.Column{
+		&renderer.Column{
+			Header: "NAME",
+		},
+		&renderer.Column{
+			Header: "URI",
+		},
+		&renderer.Column{
+			Header: "ARN",
+			Wide:   true,
+		},
+		&renderer.Column{
+			Header: "CREATEDAT",
+		},
 	}
 )
 
@@ -27,27 +34,54 @@ var repoListCmd = &cobra.Command{
 }
 
 func doRepoList(cmd *cobra.Command, args []string) error {
-	repos, err := aws.ECR.ListRepositories()
+	r, err := newRenderer()
 	if err != nil {
-		return errors.Wrap(err, "failed to fetch repository list")
+		return err
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, strings.Join(repoListHeader, "\t"))
+	ctx, cancel := newContext()
+	defer cancel()
 
-	for _, repo := range repos {
-		fmt.Fprintln(w, strings.Join([]string{
-			repo.Name,
-			repo.URI,
-			repo.CreatedAt.Local().String(),
-		}, "\t"))
+	if r.Structured() {
+		repos, err := aws.ECR.ListRepositoriesWithContext(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch repository list")
+		}
+
+		return r.Render(repos, nil)
 	}
 
-	w.Flush()
+	w, err := r.NewTableWriter(repoListColumns, nil)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader()
+
+	if err := aws.ECR.ListRepositoriesPagesWithContext(ctx, func(repos []*ecr.Repository, lastPage bool) bool {
+		for _, repo := range repos {
+			w.Write(repoListRow(repo))
+		}
+
+		w.Flush()
+
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to fetch repository list")
+	}
 
 	return nil
 }
 
+func repoListRow(repo *ecr.Repository) []string {
+	return []string{
+		repo.Name,
+		repo.URI,
+		repo.ARN,
+		formatTime(repo.CreatedAt),
+	}
+}
+
 func init() {
 	repoCmd.AddCommand(repoListCmd)
 }
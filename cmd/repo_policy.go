@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// repoPolicyCmd represents the repoPolicy command
+var repoPolicyCmd = &cobra.Command{
+	Use:   "policy <subcommand>",
+	Short: "Repository policy related commands",
+}
+
+func init() {
+	repoCmd.AddCommand(repoPolicyCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var repoPolicyDeleteOpts = struct {
+	yes bool
+}{}
+
+// repoPolicyDeleteCmd represents the repoPolicyDelete command
+var repoPolicyDeleteCmd = &cobra.Command{
+	Use:   "delete REPO",
+	Short: "Delete repository policy",
+	RunE:  doRepoPolicyDelete,
+}
+
+func doRepoPolicyDelete(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	repo := args[0]
+
+	if !repoPolicyDeleteOpts.yes {
+		ok, err := confirm(fmt.Sprintf("Delete policy of %s?", repo))
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Println("canceled")
+			return nil
+		}
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	if err := aws.ECR.DeleteRepositoryPolicyWithContext(ctx, repo); err != nil {
+		return errors.Wrapf(err, "failed to delete policy of %s", repo)
+	}
+
+	fmt.Printf("deleted: policy of %s\n", repo)
+
+	return nil
+}
+
+func init() {
+	repoPolicyCmd.AddCommand(repoPolicyDeleteCmd)
+
+	repoPolicyDeleteCmd.Flags().BoolVarP(&repoPolicyDeleteOpts.yes, "yes", "y", false, "Skip confirmation")
+}
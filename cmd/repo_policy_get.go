@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/policy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// repoPolicyGetCmd represents the repoPolicyGet command
+var repoPolicyGetCmd = &cobra.Command{
+	Use:   "get REPO",
+	Short: "Print repository policy",
+	RunE:  doRepoPolicyGet,
+}
+
+func doRepoPolicyGet(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("repository name must be given")
+	}
+	repo := args[0]
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	text, err := aws.ECR.GetRepositoryPolicyWithContext(ctx, repo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch policy of %s", repo)
+	}
+
+	if text == "" {
+		return errors.Errorf("repository %s has no policy", repo)
+	}
+
+	doc, err := policy.Parse([]byte(text))
+
+	if r.Structured() {
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse policy of %s", repo)
+		}
+
+		return r.Render(doc, nil)
+	}
+
+	if err != nil {
+		// print the policy as it is even if it cannot be parsed
+		fmt.Println(text)
+		return nil
+	}
+
+	formatted, err := policy.Format(doc)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(formatted)
+
+	return nil
+}
+
+func init() {
+	repoPolicyCmd.AddCommand(repoPolicyGetCmd)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/policy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var repoPolicySetOpts = struct {
+	yes bool
+}{}
+
+// repoPolicySetCmd represents the repoPolicySet command
+var repoPolicySetCmd = &cobra.Command{
+	Use:   "set REPO [FILE]",
+	Short: "Set repository policy",
+	Long: `Set repository policy
+
+The policy document is read from FILE, or stdin if FILE is omitted or "-".
+The difference from the current policy is printed before it is applied.
+--yes is required when the policy is read from stdin.`,
+	RunE: doRepoPolicySet,
+}
+
+func doRepoPolicySet(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if len(args) != 1 && len(args) != 2 {
+		return errors.New("repository name must be given")
+	}
+	repo := args[0]
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if len(args) == 1 || args[1] == "-" {
+		if !repoPolicySetOpts.yes {
+			return errors.New("--yes must be given to read policy from stdin")
+		}
+
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(args[1])
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to read policy")
+	}
+
+	doc, err := policy.Parse(data)
+	if err != nil {
+		return errors.Wrap(err, "invalid policy")
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	text, err := aws.ECR.GetRepositoryPolicyWithContext(ctx, repo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch policy of %s", repo)
+	}
+
+	var current map[string]interface{}
+
+	if text != "" {
+		current, err = policy.Parse([]byte(text))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse current policy of %s", repo)
+		}
+	}
+
+	lines := policy.Diff(current, doc)
+	if len(lines) == 0 {
+		fmt.Println("no changes")
+		return nil
+	}
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+
+	if !repoPolicySetOpts.yes {
+		ok, err := confirm("Apply this policy?")
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			fmt.Println("canceled")
+			return nil
+		}
+	}
+
+	formatted, err := policy.Format(doc)
+	if err != nil {
+		return err
+	}
+
+	if err := aws.ECR.SetRepositoryPolicyWithContext(ctx, repo, formatted); err != nil {
+		return errors.Wrapf(err, "failed to set policy of %s", repo)
+	}
+
+	fmt.Printf("updated: %s\n", repo)
+
+	return nil
+}
+
+func init() {
+	repoPolicyCmd.AddCommand(repoPolicySetCmd)
+
+	repoPolicySetCmd.Flags().BoolVarP(&repoPolicySetOpts.yes, "yes", "y", false, "Skip confirmation")
+}
@@ -1,25 +1,53 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/format"
+	"github.com/dtan4/ecrcli/renderer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var rootOpts = struct {
-	debug  bool
-	region string
+	bytes   bool
+	debug   bool
+	format  string
+	output  string
+	query   string
+	region  string
+	time    string
+	timeout time.Duration
+	utc     bool
 }{}
 
+// timeFormatter formats time in tables. It is initialized by --time and --utc
+var timeFormatter *format.TimeFormatter
+
+// rootCtx is canceled when ecrcli receives SIGINT or SIGTERM
+var rootCtx = context.Background()
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   "ecrcli",
 	Short: "A brief description of your application",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if err := aws.Initialize(rootOpts.region); err != nil {
+		f, err := format.NewTimeFormatter(rootOpts.time, rootOpts.utc)
+		if err != nil {
+			return err
+		}
+
+		timeFormatter = f
+
+		if err := aws.Initialize(rootOpts.region, rootOpts.timeout); err != nil {
 			return errors.Wrap(err, "failed to initialize AWS API clients")
 		}
 
@@ -30,6 +58,27 @@ var RootCmd = &cobra.Command{
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-sigCh
+		// Restore the default behavior so that the second signal kills the process,
+		// e.g. while it is blocked on reading stdin
+		signal.Stop(sigCh)
+		cancel()
+	}()
+
+	rootCtx = ctx
+
+	// Docker invokes credential helpers as docker-credential-<name> <action>
+	if filepath.Base(os.Args[0]) == credentialHelperName {
+		RootCmd.SetArgs(append([]string{credentialHelperCmd.Name()}, os.Args[1:]...))
+	}
+
 	if err := RootCmd.Execute(); err != nil {
 		if rootOpts.debug {
 			fmt.Printf("%+v\n", err)
@@ -41,11 +90,24 @@ func Execute() {
 	}
 }
 
+// newContext returns the context for API calls, which is canceled by signals
+// --timeout is not applied here but to each API request, so that long-running commands are not canceled as a whole
+func newContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(rootCtx)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	RootCmd.PersistentFlags().BoolVar(&rootOpts.bytes, "bytes", false, "Print sizes in bytes instead of human-readable units")
 	RootCmd.PersistentFlags().BoolVar(&rootOpts.debug, "debug", false, "Debug mode")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.format, "format", "", `Go template applied to each item of result (e.g. '{{.Digest}} {{join .Tags ","}}'). Functions: join, humanSize, since, json`)
+	RootCmd.PersistentFlags().StringVarP(&rootOpts.output, "output", "o", renderer.OutputTable, "Output format ("+strings.Join(renderer.Outputs, "|")+")")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.query, "query", "", "JMESPath expression applied to result (e.g. \"[?contains(Tags, 'latest')].Digest\"). The result is printed in JSON unless --output yaml or --format is given")
 	RootCmd.PersistentFlags().StringVar(&rootOpts.region, "region", "", "AWS region")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.time, "time", format.TimeRelative, "Time style in tables ("+strings.Join(format.TimeStyles, "|")+")")
+	RootCmd.PersistentFlags().DurationVar(&rootOpts.timeout, "timeout", 0, "Timeout of each API request (e.g. 30s, 1m). Commands making many requests may take longer in total. 0 means no timeout")
+	RootCmd.PersistentFlags().BoolVar(&rootOpts.utc, "utc", false, "Print absolute time in UTC instead of local time")
 }
 
 // initConfig reads in config file and ENV variables if set.
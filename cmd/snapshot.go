@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <subcommand>",
+	Short: "Registry inventory snapshot related commands",
+}
+
+func init() {
+	RootCmd.AddCommand(snapshotCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/dtan4/ecrcli/snapshot"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// snapshotDiffCmd represents the snapshotDiff command
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Print differences between two snapshots",
+	Long: `Print differences between two snapshots
+
+Added and removed repositories, added and removed image digests, and tags which point to another digest are printed.
+Images of added or removed repositories are also printed as added or removed images.`,
+	RunE: doSnapshotDiff,
+}
+
+func doSnapshotDiff(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("two snapshot files must be given")
+	}
+
+	a, err := loadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := loadSnapshot(args[1])
+	if err != nil {
+		return err
+	}
+
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	d := snapshot.Compare(a, b)
+
+	if r.Structured() {
+		return r.Render(d, nil)
+	}
+
+	if d.Empty() {
+		fmt.Println("no differences")
+		return nil
+	}
+
+	for _, name := range d.AddedRepositories {
+		fmt.Printf("+ repository %s\n", name)
+	}
+
+	for _, name := range d.RemovedRepositories {
+		fmt.Printf("- repository %s\n", name)
+	}
+
+	for _, image := range d.AddedImages {
+		fmt.Printf("+ image %s@%s [%s]\n", image.Repository, image.Digest, strings.Join(image.Tags, ","))
+	}
+
+	for _, image := range d.RemovedImages {
+		fmt.Printf("- image %s@%s [%s]\n", image.Repository, image.Digest, strings.Join(image.Tags, ","))
+	}
+
+	for _, move := range d.MovedTags {
+		fmt.Printf("~ tag %s:%s %s -> %s\n", move.Repository, move.Tag, move.From, move.To)
+	}
+
+	return nil
+}
+
+// loadSnapshot reads snapshot from the given file
+func loadSnapshot(file string) (*snapshot.Snapshot, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", file)
+	}
+
+	s, err := snapshot.Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid snapshot %s", file)
+	}
+
+	return s, nil
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/snapshot"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// snapshotSaveCmd represents the snapshotSave command
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save FILE",
+	Short: "Save all repositories and images to snapshot file",
+	Long: `Save all repositories and images to snapshot file
+
+Snapshot contains digest, tags, size and push time of every image in every repository, in JSON.
+Snapshot is printed to stdout if FILE is "-".`,
+	RunE: doSnapshotSave,
+}
+
+func doSnapshotSave(cmd *cobra.Command, args []string) error {
+	if err := checkNotRendered(cmd); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("snapshot file must be given")
+	}
+	file := args[0]
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	repos, err := aws.ECR.ListRepositoriesWithContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch repository list")
+	}
+
+	s := snapshot.New(time.Now().UTC())
+
+	for _, repo := range repos {
+		images, err := aws.ECR.ListImagesWithContext(ctx, repo.Name, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch image list of %s", repo.Name)
+		}
+
+		s.AddRepository(repo.Name, images)
+	}
+
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if file == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", file)
+	}
+
+	fmt.Printf("saved %d repositories and %d images to %s\n", len(s.Repositories), s.ImageCount(), file)
+
+	return nil
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+}
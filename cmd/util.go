@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws"
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/format"
+	"github.com/dtan4/ecrcli/renderer"
+	"github.com/dtan4/ecrcli/tokencache"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// checkNotRendered returns error if --output, --format or --query is given to the command which does not render its result
+func checkNotRendered(cmd *cobra.Command) error {
+	if f := cmd.Flags().Lookup("output"); f != nil && f.Changed && rootOpts.output != renderer.OutputTable {
+		return errors.Errorf("%s does not support --output", cmd.CommandPath())
+	}
+
+	for _, name := range []string{"format", "query"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return errors.Errorf("%s does not support --%s", cmd.CommandPath(), name)
+		}
+	}
+
+	return nil
+}
+
+// confirm asks the user yes or no with the given message, and returns true if the user answered yes
+func confirm(message string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", message)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read answer")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// formatSize returns size in human-readable units, or in bytes if --bytes is given
+func formatSize(bytes int64) string {
+	if rootOpts.bytes {
+		return strconv.FormatInt(bytes, 10)
+	}
+
+	return format.Size(bytes)
+}
+
+// formatTime returns time in the style given by --time and --utc
+func formatTime(t time.Time) string {
+	return timeFormatter.Format(t)
+}
+
+// getCredentials returns ECR credentials for the given registries in the same order
+// If no registry ID is given, the credentials for the default registry are returned
+// Credentials are cached on disk and reused until shortly before they expire, unless noCache is true
+func getCredentials(ctx context.Context, registryIDs []string, noCache bool) ([]*ecr.Credentials, error) {
+	accounts := registryIDs
+	if len(accounts) == 0 {
+		accounts = []string{tokencache.DefaultAccount}
+	}
+
+	var (
+		cache       *tokencache.Cache
+		accessKeyID string
+	)
+
+	if !noCache {
+		dir, dirErr := tokencache.DefaultDir()
+		id, idErr := aws.AccessKeyID()
+
+		// without access key ID, cached credentials of another identity could be returned
+		if dirErr == nil && idErr == nil {
+			cache = tokencache.New(dir)
+			accessKeyID = id
+		}
+	}
+
+	result := make([]*ecr.Credentials, len(accounts))
+	missing := []int{}
+	now := time.Now()
+
+	for i, account := range accounts {
+		if cache != nil {
+			if creds, ok := cache.Get(tokencache.Key(account, aws.Region(), tokencache.Profile(), accessKeyID), now); ok {
+				result[i] = creds
+				continue
+			}
+		}
+
+		missing = append(missing, i)
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	ids := []string{}
+
+	if len(registryIDs) > 0 {
+		for _, i := range missing {
+			ids = append(ids, registryIDs[i])
+		}
+	}
+
+	credentials, err := aws.ECR.GetCredentialsWithContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range missing {
+		result[i] = credentials[j]
+
+		if cache == nil {
+			continue
+		}
+
+		if err := cache.Set(tokencache.Key(accounts[i], aws.Region(), tokencache.Profile(), accessKeyID), credentials[j]); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to cache credentials: %s\n", err)
+		}
+	}
+
+	return result, nil
+}
+
+// newRenderer creates Renderer object printing to stdout in the format given by --output
+func newRenderer() (*renderer.Renderer, error) {
+	return renderer.New(os.Stdout, &renderer.Options{
+		Output: rootOpts.output,
+		Format: rootOpts.format,
+		Query:  rootOpts.query,
+	})
+}
+
+// parseTimeFlag parses the given string as RFC3339 timestamp, date (2006-01-02) or duration before now
+func parseTimeFlag(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, errors.Errorf("%q must be RFC3339 timestamp, date (2006-01-02) or duration (e.g. 72h)", s)
+}
+
+// parseImageRef splits REPO:TAG or REPO@DIGEST into repository name and tag or digest
+// "latest" is used if neither tag nor digest is given
+func parseImageRef(s string) (string, string, error) {
+	var repo, ref string
+
+	if i := strings.Index(s, "@"); i >= 0 {
+		repo, ref = s[:i], s[i+1:]
+	} else if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		repo, ref = s[:i], s[i+1:]
+	} else {
+		repo, ref = s, "latest"
+	}
+
+	if repo == "" || ref == "" {
+		return "", "", errors.Errorf("invalid image reference %q. must be REPO:TAG or REPO@DIGEST", s)
+	}
+
+	return repo, ref, nil
+}
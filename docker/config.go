@@ -0,0 +1,195 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ecrcli/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	configFileName = "config.json"
+)
+
+// Auth represents the credentials for a registry server
+type Auth struct {
+	Server   string
+	Username string
+	Password string
+}
+
+// authEntry represents the entry of auths in config.json
+type authEntry struct {
+	Auth string `json:"auth"`
+}
+
+// ConfigJSON returns Docker config JSON which contains only auths of the given credentials
+func ConfigJSON(auths []*Auth) ([]byte, error) {
+	entries := map[string]*authEntry{}
+
+	for _, a := range auths {
+		entries[a.Server] = newAuthEntry(a.Username, a.Password)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"auths": entries,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode Docker config")
+	}
+
+	return data, nil
+}
+
+// ConfigDir returns the directory of Docker config, $DOCKER_CONFIG or ~/.docker
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+
+	home, err := fileutil.HomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".docker"), nil
+}
+
+// WriteAuth merges the credentials for the given server into config.json in dir
+// Other keys in config.json are preserved as they are
+// It refuses to write if Docker uses a credential helper for the server, because auths are ignored then
+func WriteAuth(dir, server, username, password string) (string, error) {
+	path := filepath.Join(dir, configFileName)
+
+	config, err := readConfig(path)
+	if err != nil {
+		return "", err
+	}
+
+	helper, err := credentialHelper(config, server)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	if helper != "" {
+		return "", errors.Errorf("Docker uses credential helper %q for %s as configured in %s, so credentials in auths would be ignored. use docker login --password-stdin instead", helper, hostname(server), path)
+	}
+
+	auths := map[string]json.RawMessage{}
+
+	if raw, ok := config["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return "", errors.Wrapf(err, "failed to parse auths in %s", path)
+		}
+
+		if auths == nil {
+			auths = map[string]json.RawMessage{}
+		}
+	}
+
+	auth, err := json.Marshal(newAuthEntry(username, password))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode auth")
+	}
+
+	auths[server] = auth
+
+	raw, err := json.Marshal(auths)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode auths")
+	}
+
+	config["auths"] = raw
+
+	data, err := json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode Docker config")
+	}
+
+	if err := fileutil.WriteFileAtomic(path, append(data, '\n'), 0600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// credentialHelper returns the name of credential helper Docker uses for the server, or empty string if none
+// credHelpers for the server takes precedence over credsStore
+func credentialHelper(config map[string]json.RawMessage, server string) (string, error) {
+	if raw, ok := config["credHelpers"]; ok {
+		helpers := map[string]string{}
+
+		if err := json.Unmarshal(raw, &helpers); err != nil {
+			return "", errors.Wrap(err, "failed to parse credHelpers")
+		}
+
+		for _, key := range []string{server, hostname(server)} {
+			if helper := helpers[key]; helper != "" {
+				return helper, nil
+			}
+		}
+	}
+
+	if raw, ok := config["credsStore"]; ok {
+		var store string
+
+		if err := json.Unmarshal(raw, &store); err != nil {
+			return "", errors.Wrap(err, "failed to parse credsStore")
+		}
+
+		return store, nil
+	}
+
+	return "", nil
+}
+
+// hostname returns the hostname of server address (e.g. https://012345678910.dkr.ecr.us-east-1.amazonaws.com)
+func hostname(server string) string {
+	if i := strings.Index(server, "://"); i >= 0 {
+		server = server[i+3:]
+	}
+
+	if i := strings.Index(server, "/"); i >= 0 {
+		server = server[:i]
+	}
+
+	return server
+}
+
+func newAuthEntry(username, password string) *authEntry {
+	return &authEntry{
+		Auth: base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+}
+
+func readConfig(path string) (map[string]json.RawMessage, error) {
+	config := map[string]json.RawMessage{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	if len(data) == 0 {
+		return config, nil
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	if config == nil {
+		config = map[string]json.RawMessage{}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,216 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigJSON(t *testing.T) {
+	auths := []*Auth{
+		&Auth{
+			Server:   "https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+			Username: "AWS",
+			Password: "password",
+		},
+		&Auth{
+			Server:   "https://109876543210.dkr.ecr.us-east-1.amazonaws.com",
+			Username: "AWS",
+			Password: "foo",
+		},
+	}
+
+	expected := `{"auths":{"https://012345678910.dkr.ecr.us-east-1.amazonaws.com":{"auth":"QVdTOnBhc3N3b3Jk"},"https://109876543210.dkr.ecr.us-east-1.amazonaws.com":{"auth":"QVdTOmZvbw=="}}}`
+
+	got, err := ConfigJSON(auths)
+	if err != nil {
+		t.Errorf("error should not be raised: %s", err)
+	}
+
+	if string(got) != expected {
+		t.Errorf("config does not match. expected: %q, got: %q", expected, string(got))
+	}
+}
+
+func TestConfigDir(t *testing.T) {
+	orig := os.Getenv("DOCKER_CONFIG")
+	defer os.Setenv("DOCKER_CONFIG", orig)
+
+	os.Setenv("DOCKER_CONFIG", "/tmp/docker-config")
+
+	got, err := ConfigDir()
+	if err != nil {
+		t.Errorf("error should not be raised: %s", err)
+	}
+
+	if got != "/tmp/docker-config" {
+		t.Errorf("config dir does not match. expected: %q, got: %q", "/tmp/docker-config", got)
+	}
+}
+
+func TestWriteAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecrcli-docker")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	existing := `{
+	"auths": {
+		"https://index.docker.io/v1/": {"auth": "Zm9vOmJhcg=="},
+		"https://012345678910.dkr.ecr.us-east-1.amazonaws.com": {"auth": "b2xkOm9sZA=="}
+	},
+	"detachKeys": "ctrl-e,e"
+}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(existing), 0644); err != nil {
+		t.Fatalf("failed to write config.json: %s", err)
+	}
+
+	path, err := WriteAuth(dir, "https://012345678910.dkr.ecr.us-east-1.amazonaws.com", "AWS", "password")
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	if path != filepath.Join(dir, "config.json") {
+		t.Errorf("path does not match. expected: %q, got: %q", filepath.Join(dir, "config.json"), path)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config.json: %s", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to parse config.json: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"auths": map[string]interface{}{
+			"https://index.docker.io/v1/": map[string]interface{}{
+				"auth": "Zm9vOmJhcg==",
+			},
+			"https://012345678910.dkr.ecr.us-east-1.amazonaws.com": map[string]interface{}{
+				"auth": "QVdTOnBhc3N3b3Jk",
+			},
+		},
+		"detachKeys": "ctrl-e,e",
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("config does not match. expected: %#v, got: %#v", expected, got)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat config.json: %s", err)
+	}
+
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("file mode does not match. expected: %o, got: %o", 0600, fi.Mode().Perm())
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %s", err)
+	}
+
+	if len(files) != 1 {
+		t.Errorf("temporary file should be removed. got %d files", len(files))
+	}
+}
+
+func TestWriteAuth_newFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecrcli-docker")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path, err := WriteAuth(filepath.Join(dir, ".docker"), "https://012345678910.dkr.ecr.us-east-1.amazonaws.com", "AWS", "password")
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config.json: %s", err)
+	}
+
+	expected := `{
+	"auths": {
+		"https://012345678910.dkr.ecr.us-east-1.amazonaws.com": {
+			"auth": "QVdTOnBhc3N3b3Jk"
+		}
+	}
+}
+`
+	if string(data) != expected {
+		t.Errorf("config does not match. expected: %q, got: %q", expected, string(data))
+	}
+}
+
+func TestWriteAuth_credentialHelper(t *testing.T) {
+	testcases := []struct {
+		config string
+		ok     bool
+	}{
+		{
+			config: `{"auths":{},"credsStore":"desktop"}`,
+			ok:     false,
+		},
+		{
+			config: `{"credHelpers":{"012345678910.dkr.ecr.us-east-1.amazonaws.com":"ecr-login"}}`,
+			ok:     false,
+		},
+		{
+			config: `{"credHelpers":{"gcr.io":"gcloud"}}`,
+			ok:     true,
+		},
+		{
+			config: `{"credsStore":""}`,
+			ok:     true,
+		},
+	}
+
+	for _, tc := range testcases {
+		dir, err := ioutil.TempDir("", "ecrcli-docker")
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %s", err)
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.json")
+
+		if err := ioutil.WriteFile(path, []byte(tc.config), 0600); err != nil {
+			t.Fatalf("failed to write config.json: %s", err)
+		}
+
+		_, err = WriteAuth(dir, "https://012345678910.dkr.ecr.us-east-1.amazonaws.com", "AWS", "password")
+
+		if tc.ok {
+			if err != nil {
+				t.Errorf("error should not be raised for %s: %s", tc.config, err)
+			}
+
+			continue
+		}
+
+		if err == nil {
+			t.Errorf("error should be raised for %s", tc.config)
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read config.json: %s", err)
+		}
+
+		if string(data) != tc.config {
+			t.Errorf("config.json should not be changed. expected: %q, got: %q", tc.config, string(data))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package fileutil
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// HomeDir returns the home directory of the current user
+func HomeDir() (string, error) {
+	if home := os.Getenv("HOME"); home != "" {
+		return home, nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve home directory")
+	}
+
+	return u.HomeDir, nil
+}
+
+// WriteFileAtomic writes data to a temporary file in the same directory and renames it to path,
+// so that readers never see a partially written file
+// The directory is created with 0700 if it does not exist
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create %s", dir)
+	}
+
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file in %s", dir)
+	}
+
+	tmp := f.Name()
+
+	// change mode before writing, as data may contain secrets
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to change mode of %s", tmp)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to sync %s", tmp)
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to close %s", tmp)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to rename %s to %s", tmp, path)
+	}
+
+	return nil
+}
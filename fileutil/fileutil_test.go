@@ -0,0 +1,51 @@
+package fileutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecrcli-fileutil")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sub", "config.json")
+
+	for _, content := range []string{"foo", "bar"} {
+		if err := WriteFileAtomic(path, []byte(content), 0600); err != nil {
+			t.Fatalf("error should not be raised: %s", err)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read file: %s", err)
+		}
+
+		if string(data) != content {
+			t.Errorf("content does not match. expected: %q, got: %q", content, string(data))
+		}
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %s", err)
+	}
+
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("file mode does not match. expected: %o, got: %o", 0600, fi.Mode().Perm())
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "sub"))
+	if err != nil {
+		t.Fatalf("failed to read directory: %s", err)
+	}
+
+	if len(files) != 1 {
+		t.Errorf("temporary file should be removed. got %d files", len(files))
+	}
+}
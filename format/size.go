@@ -0,0 +1,42 @@
+package format
+
+import (
+	"fmt"
+)
+
+const (
+	// sizeUnitThreshold is the largest value printed in the current unit.
+	// Values which would be rounded to "1024.0" are printed in the next unit
+	sizeUnitThreshold = 1023.95
+)
+
+var (
+	sizeUnits = []string{
+		"KiB",
+		"MiB",
+		"GiB",
+		"TiB",
+		"PiB",
+	}
+)
+
+// Size returns human-readable size in binary units (e.g. "178.0 MiB")
+func Size(bytes int64) string {
+	if bytes < 1024 && bytes > -1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	v := float64(bytes) / 1024
+	unit := sizeUnits[0]
+
+	for _, u := range sizeUnits[1:] {
+		if v < sizeUnitThreshold && v > -sizeUnitThreshold {
+			break
+		}
+
+		v /= 1024
+		unit = u
+	}
+
+	return fmt.Sprintf("%.1f %s", v, unit)
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+)
+
+func TestSize(t *testing.T) {
+	testcases := []struct {
+		bytes    int64
+		expected string
+	}{
+		{
+			bytes:    0,
+			expected: "0 B",
+		},
+		{
+			bytes:    1023,
+			expected: "1023 B",
+		},
+		{
+			bytes:    1024,
+			expected: "1.0 KiB",
+		},
+		{
+			bytes:    1048575,
+			expected: "1.0 MiB",
+		},
+		{
+			bytes:    1048524,
+			expected: "1023.9 KiB",
+		},
+		{
+			bytes:    186629610,
+			expected: "178.0 MiB",
+		},
+		{
+			bytes:    5368709120,
+			expected: "5.0 GiB",
+		},
+		{
+			bytes:    1649267441664,
+			expected: "1.5 TiB",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := Size(tc.bytes); got != tc.expected {
+			t.Errorf("size of %d does not match. expected: %q, got: %q", tc.bytes, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TimeRelative formats time relative to now (e.g. "3 days ago")
+	TimeRelative = "relative"
+	// TimeRFC3339 formats time in RFC3339 (e.g. "2017-07-20T15:40:05+09:00")
+	TimeRFC3339 = "rfc3339"
+	// TimeUnix formats time in Unix seconds (e.g. "1500532805")
+	TimeUnix = "unix"
+
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365 * day
+)
+
+var (
+	// TimeStyles is the list of supported time styles
+	TimeStyles = []string{
+		TimeRelative,
+		TimeRFC3339,
+		TimeUnix,
+	}
+)
+
+// TimeFormatter formats time in the specified style
+type TimeFormatter struct {
+	style string
+	utc   bool
+	now   func() time.Time
+}
+
+// NewTimeFormatter creates new TimeFormatter object
+// If utc is true, absolute time is printed in UTC, otherwise in local time
+func NewTimeFormatter(style string, utc bool) (*TimeFormatter, error) {
+	switch style {
+	case TimeRelative, TimeRFC3339, TimeUnix:
+	default:
+		return nil, errors.Errorf("time style must be one of %s. got: %q", strings.Join(TimeStyles, ", "), style)
+	}
+
+	return &TimeFormatter{
+		style: style,
+		utc:   utc,
+		now:   time.Now,
+	}, nil
+}
+
+// Format returns the formatted time. "-" is returned for zero time
+func (f *TimeFormatter) Format(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	switch f.style {
+	case TimeRelative:
+		return Relative(t, f.now())
+	case TimeUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+
+	if f.utc {
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
+
+// Relative returns human-readable time relative to now (e.g. "3 days ago")
+func Relative(t, now time.Time) string {
+	d := now.Sub(t)
+
+	if d < 0 {
+		return "in " + duration(-d)
+	}
+
+	if d < time.Second {
+		return "just now"
+	}
+
+	return duration(d) + " ago"
+}
+
+func duration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return plural(int64(d/time.Second), "second")
+	case d < time.Hour:
+		return plural(int64(d/time.Minute), "minute")
+	case d < day:
+		return plural(int64(d/time.Hour), "hour")
+	case d < month:
+		return plural(int64(d/day), "day")
+	case d < year:
+		return plural(int64(d/month), "month")
+	default:
+		return plural(int64(d/year), "year")
+	}
+}
+
+func plural(n int64, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,107 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelative(t *testing.T) {
+	now := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	testcases := []struct {
+		t        time.Time
+		expected string
+	}{
+		{
+			t:        now,
+			expected: "just now",
+		},
+		{
+			t:        now.Add(-30 * time.Second),
+			expected: "30 seconds ago",
+		},
+		{
+			t:        now.Add(-1 * time.Minute),
+			expected: "1 minute ago",
+		},
+		{
+			t:        now.Add(-5 * time.Hour),
+			expected: "5 hours ago",
+		},
+		{
+			t:        now.Add(-72 * time.Hour),
+			expected: "3 days ago",
+		},
+		{
+			t:        now.Add(-65 * 24 * time.Hour),
+			expected: "2 months ago",
+		},
+		{
+			t:        now.Add(-800 * 24 * time.Hour),
+			expected: "2 years ago",
+		},
+		{
+			t:        now.Add(2 * time.Hour),
+			expected: "in 2 hours",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := Relative(tc.t, now); got != tc.expected {
+			t.Errorf("relative time of %s does not match. expected: %q, got: %q", tc.t, tc.expected, got)
+		}
+	}
+}
+
+func TestTimeFormatter(t *testing.T) {
+	now := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	testcases := []struct {
+		style    string
+		utc      bool
+		t        time.Time
+		expected string
+	}{
+		{
+			style:    TimeRelative,
+			t:        now.Add(-72 * time.Hour),
+			expected: "3 days ago",
+		},
+		{
+			style:    TimeRFC3339,
+			utc:      true,
+			t:        now,
+			expected: "2017-07-20T06:40:05Z",
+		},
+		{
+			style:    TimeUnix,
+			t:        now,
+			expected: "1500532805",
+		},
+		{
+			style:    TimeUnix,
+			t:        time.Time{},
+			expected: "-",
+		},
+	}
+
+	for _, tc := range testcases {
+		f, err := NewTimeFormatter(tc.style, tc.utc)
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		f.now = func() time.Time {
+			return now
+		}
+
+		if got := f.Format(tc.t); got != tc.expected {
+			t.Errorf("%s time does not match. expected: %q, got: %q", tc.style, tc.expected, got)
+		}
+	}
+
+	if _, err := NewTimeFormatter("iso8601", false); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+)
+
+const (
+	// SecretTypeDockerConfigJSON is the type of Secret used as imagePullSecrets
+	SecretTypeDockerConfigJSON = "kubernetes.io/dockerconfigjson"
+
+	dockerConfigJSONKey = ".dockerconfigjson"
+)
+
+// Secret represents Kubernetes Secret manifest
+type Secret struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   *ObjectMeta       `json:"metadata"`
+	Type       string            `json:"type"`
+	Data       map[string]string `json:"data"`
+}
+
+// ObjectMeta represents the metadata of Kubernetes object
+type ObjectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NewDockerConfigSecret creates new Secret of kubernetes.io/dockerconfigjson type with the given Docker config JSON
+// Namespace is omitted if empty
+func NewDockerConfigSecret(name, namespace string, dockerConfigJSON []byte) *Secret {
+	return &Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: &ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: SecretTypeDockerConfigJSON,
+		Data: map[string]string{
+			dockerConfigJSONKey: base64.StdEncoding.EncodeToString(dockerConfigJSON),
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewDockerConfigSecret(t *testing.T) {
+	testcases := []struct {
+		namespace string
+		expected  string
+	}{
+		{
+			namespace: "kube-system",
+			expected:  `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"ecr","namespace":"kube-system"},"type":"kubernetes.io/dockerconfigjson","data":{".dockerconfigjson":"eyJhdXRocyI6e319"}}`,
+		},
+		{
+			namespace: "",
+			expected:  `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"ecr"},"type":"kubernetes.io/dockerconfigjson","data":{".dockerconfigjson":"eyJhdXRocyI6e319"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		secret := NewDockerConfigSecret("ecr", tc.namespace, []byte(`{"auths":{}}`))
+
+		got, err := json.Marshal(secret)
+		if err != nil {
+			t.Errorf("error should not be raised: %s", err)
+			continue
+		}
+
+		if string(got) != tc.expected {
+			t.Errorf("secret does not match. expected: %q, got: %q", tc.expected, string(got))
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TagStatusTagged selects images which have tags matching all prefixes in tagPrefixList
+	TagStatusTagged = "tagged"
+	// TagStatusUntagged selects untagged images
+	TagStatusUntagged = "untagged"
+	// TagStatusAny selects all images
+	TagStatusAny = "any"
+
+	// CountTypeImageCountMoreThan expires images beyond the newest countNumber images
+	CountTypeImageCountMoreThan = "imageCountMoreThan"
+	// CountTypeSinceImagePushed expires images pushed more than countNumber days ago
+	CountTypeSinceImagePushed = "sinceImagePushed"
+
+	countUnitDays = "days"
+
+	actionTypeExpire = "expire"
+)
+
+// Policy represents ECR lifecycle policy
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule represents the rule of lifecycle policy
+type Rule struct {
+	RulePriority int        `json:"rulePriority"`
+	Description  string     `json:"description,omitempty"`
+	Selection    *Selection `json:"selection"`
+	Action       *Action    `json:"action"`
+}
+
+// Selection represents the selection criteria of rule
+type Selection struct {
+	TagStatus     string   `json:"tagStatus"`
+	TagPrefixList []string `json:"tagPrefixList,omitempty"`
+	CountType     string   `json:"countType"`
+	CountUnit     string   `json:"countUnit,omitempty"`
+	CountNumber   int      `json:"countNumber"`
+}
+
+// Action represents the action of rule
+type Action struct {
+	Type string `json:"type"`
+}
+
+// Result represents the images expired by a rule
+type Result struct {
+	Rule    *Rule        `json:"Rule"`
+	Expired []*ecr.Image `json:"Expired"`
+}
+
+// Parse parses the given bytes as lifecycle policy and validates it
+func Parse(data []byte) (*Policy, error) {
+	var policy Policy
+
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, errors.Wrap(err, "failed to parse lifecycle policy")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate validates the policy in the same way as ECR
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("rules must not be empty")
+	}
+
+	priorities := map[int]bool{}
+	maxPriority := 0
+
+	for i, r := range p.Rules {
+		if r == nil {
+			return errors.Errorf("rules[%d] must be an object", i)
+		}
+
+		if err := r.validate(); err != nil {
+			return errors.Wrapf(err, "invalid rule (rulePriority: %d)", r.RulePriority)
+		}
+
+		if priorities[r.RulePriority] {
+			return errors.Errorf("rulePriority must be unique. got %d twice", r.RulePriority)
+		}
+
+		priorities[r.RulePriority] = true
+
+		if r.RulePriority > maxPriority {
+			maxPriority = r.RulePriority
+		}
+	}
+
+	for _, r := range p.Rules {
+		if r.Selection.TagStatus == TagStatusAny && r.RulePriority != maxPriority {
+			return errors.Errorf("rule with tagStatus any must have the highest rulePriority. got %d", r.RulePriority)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if r.RulePriority < 1 {
+		return errors.New("rulePriority must be a positive integer")
+	}
+
+	if r.Selection == nil {
+		return errors.New("selection must be given")
+	}
+
+	s := r.Selection
+
+	switch s.TagStatus {
+	case TagStatusTagged:
+		if len(s.TagPrefixList) == 0 {
+			return errors.New("tagPrefixList must be given if tagStatus is tagged")
+		}
+	case TagStatusUntagged, TagStatusAny:
+		if len(s.TagPrefixList) > 0 {
+			return errors.New("tagPrefixList can be given only if tagStatus is tagged")
+		}
+	default:
+		return errors.Errorf("tagStatus must be one of tagged, untagged and any. got: %q", s.TagStatus)
+	}
+
+	switch s.CountType {
+	case CountTypeImageCountMoreThan:
+		if s.CountUnit != "" {
+			return errors.New("countUnit must not be given if countType is imageCountMoreThan")
+		}
+	case CountTypeSinceImagePushed:
+		if s.CountUnit != countUnitDays {
+			return errors.Errorf("countUnit must be days if countType is sinceImagePushed. got: %q", s.CountUnit)
+		}
+	default:
+		return errors.Errorf("countType must be imageCountMoreThan or sinceImagePushed. got: %q", s.CountType)
+	}
+
+	if s.CountNumber < 1 {
+		return errors.New("countNumber must be a positive integer")
+	}
+
+	if r.Action == nil || r.Action.Type != actionTypeExpire {
+		return errors.New("action type must be expire")
+	}
+
+	return nil
+}
+
+// Evaluate returns the images each rule would expire, in order of rulePriority
+// Like ECR, an image matching the selection of a rule is never expired by rules with lower priority,
+// even if the rule itself does not expire it
+func Evaluate(policy *Policy, images []*ecr.Image, now time.Time) []*Result {
+	rules := make([]*Rule, len(policy.Rules))
+	copy(rules, policy.Rules)
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].RulePriority < rules[j].RulePriority
+	})
+
+	remaining := make([]*ecr.Image, len(images))
+	copy(remaining, images)
+
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].PushedAt.After(remaining[j].PushedAt)
+	})
+
+	results := []*Result{}
+
+	for _, rule := range rules {
+		matched := []*ecr.Image{}
+		rest := []*ecr.Image{}
+
+		for _, image := range remaining {
+			if rule.Selection.match(image) {
+				matched = append(matched, image)
+			} else {
+				rest = append(rest, image)
+			}
+		}
+
+		results = append(results, &Result{
+			Rule:    rule,
+			Expired: rule.Selection.expire(matched, now),
+		})
+
+		remaining = rest
+	}
+
+	return results
+}
+
+// match returns whether the image satisfies tagStatus and tagPrefixList
+func (s *Selection) match(image *ecr.Image) bool {
+	switch s.TagStatus {
+	case TagStatusUntagged:
+		return len(image.Tags) == 0
+	case TagStatusTagged:
+		for _, prefix := range s.TagPrefixList {
+			if !hasTagWithPrefix(image.Tags, prefix) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return true
+	}
+}
+
+// expire returns images to be expired among matched images, which are sorted newest first
+func (s *Selection) expire(matched []*ecr.Image, now time.Time) []*ecr.Image {
+	expired := []*ecr.Image{}
+
+	switch s.CountType {
+	case CountTypeImageCountMoreThan:
+		if len(matched) > s.CountNumber {
+			expired = append(expired, matched[s.CountNumber:]...)
+		}
+	case CountTypeSinceImagePushed:
+		threshold := now.Add(-time.Duration(s.CountNumber) * 24 * time.Hour)
+
+		for _, image := range matched {
+			if image.PushedAt.Before(threshold) {
+				expired = append(expired, image)
+			}
+		}
+	}
+
+	return expired
+}
+
+func hasTagWithPrefix(tags []string, prefix string) bool {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
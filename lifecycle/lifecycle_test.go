@@ -0,0 +1,182 @@
+package lifecycle
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		data string
+		ok   bool
+	}{
+		{
+			data: `{"rules":[{"rulePriority":1,"description":"keep 10 prod images","selection":{"tagStatus":"tagged","tagPrefixList":["prod"],"countType":"imageCountMoreThan","countNumber":10},"action":{"type":"expire"}},{"rulePriority":2,"selection":{"tagStatus":"any","countType":"sinceImagePushed","countUnit":"days","countNumber":14},"action":{"type":"expire"}}]}`,
+			ok:   true,
+		},
+		{
+			data: `{"rules":[]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[null]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":1},"action":{"type":"expire"}},null]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"tagged","countType":"imageCountMoreThan","countNumber":10},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"sinceImagePushed","countNumber":10},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countUnit":"days","countNumber":10},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":0},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":1},"action":{"type":"delete"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":1},"action":{"type":"expire"}},{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":2},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"any","countType":"imageCountMoreThan","countNumber":1},"action":{"type":"expire"}},{"rulePriority":2,"selection":{"tagStatus":"untagged","countType":"imageCountMoreThan","countNumber":2},"action":{"type":"expire"}}]}`,
+			ok:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := Parse([]byte(tc.data))
+
+		if tc.ok && err != nil {
+			t.Errorf("error should not be raised for %s: %s", tc.data, err)
+		}
+
+		if !tc.ok && err == nil {
+			t.Errorf("error should be raised for %s", tc.data)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	prod1 := &ecr.Image{Digest: "sha256:prod1", Tags: []string{"prod-1"}, PushedAt: now.Add(-30 * 24 * time.Hour)}
+	prod2 := &ecr.Image{Digest: "sha256:prod2", Tags: []string{"prod-2"}, PushedAt: now.Add(-20 * 24 * time.Hour)}
+	prod3 := &ecr.Image{Digest: "sha256:prod3", Tags: []string{"prod-3", "latest"}, PushedAt: now.Add(-10 * 24 * time.Hour)}
+	dev1 := &ecr.Image{Digest: "sha256:dev1", Tags: []string{"dev-1"}, PushedAt: now.Add(-15 * 24 * time.Hour)}
+	dev2 := &ecr.Image{Digest: "sha256:dev2", Tags: []string{"dev-2"}, PushedAt: now.Add(-1 * 24 * time.Hour)}
+	untagged1 := &ecr.Image{Digest: "sha256:untagged1", Tags: []string{}, PushedAt: now.Add(-40 * 24 * time.Hour)}
+	untagged2 := &ecr.Image{Digest: "sha256:untagged2", Tags: []string{}, PushedAt: now.Add(-2 * 24 * time.Hour)}
+
+	images := []*ecr.Image{prod1, prod2, prod3, dev1, dev2, untagged1, untagged2}
+
+	policy := &Policy{
+		Rules: []*Rule{
+			&Rule{
+				RulePriority: 20,
+				Selection: &Selection{
+					TagStatus:   TagStatusAny,
+					CountType:   CountTypeSinceImagePushed,
+					CountUnit:   "days",
+					CountNumber: 14,
+				},
+				Action: &Action{Type: "expire"},
+			},
+			&Rule{
+				RulePriority: 10,
+				Selection: &Selection{
+					TagStatus:     TagStatusTagged,
+					TagPrefixList: []string{"prod"},
+					CountType:     CountTypeImageCountMoreThan,
+					CountNumber:   2,
+				},
+				Action: &Action{Type: "expire"},
+			},
+			&Rule{
+				RulePriority: 15,
+				Selection: &Selection{
+					TagStatus:   TagStatusUntagged,
+					CountType:   CountTypeImageCountMoreThan,
+					CountNumber: 1,
+				},
+				Action: &Action{Type: "expire"},
+			},
+		},
+	}
+
+	got := Evaluate(policy, images, now)
+
+	expected := [][]*ecr.Image{
+		// prod2 is older than 14 days, but kept because prod* images are matched by the rule with higher priority
+		[]*ecr.Image{prod1},
+		[]*ecr.Image{untagged1},
+		[]*ecr.Image{dev1},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("number of results does not match. expected: %d, got: %d", len(expected), len(got))
+	}
+
+	for i, result := range got {
+		if result.Rule != policy.Rules[[]int{1, 2, 0}[i]] {
+			t.Errorf("rule of result[%d] does not match. got rulePriority %d", i, result.Rule.RulePriority)
+		}
+
+		if !reflect.DeepEqual(result.Expired, expected[i]) {
+			t.Errorf("expired images of rulePriority %d does not match. expected: %v, got: %v", result.Rule.RulePriority, digests(expected[i]), digests(result.Expired))
+		}
+	}
+}
+
+func TestEvaluate_multiplePrefixes(t *testing.T) {
+	now := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	both := &ecr.Image{Digest: "sha256:both", Tags: []string{"alpha-1", "beta-1"}, PushedAt: now.Add(-2 * time.Hour)}
+	alpha := &ecr.Image{Digest: "sha256:alpha", Tags: []string{"alpha-2"}, PushedAt: now.Add(-1 * time.Hour)}
+
+	policy := &Policy{
+		Rules: []*Rule{
+			&Rule{
+				RulePriority: 1,
+				Selection: &Selection{
+					TagStatus:     TagStatusTagged,
+					TagPrefixList: []string{"alpha", "beta"},
+					CountType:     CountTypeImageCountMoreThan,
+					CountNumber:   1,
+				},
+				Action: &Action{Type: "expire"},
+			},
+		},
+	}
+
+	got := Evaluate(policy, []*ecr.Image{both, alpha}, now)
+
+	if len(got[0].Expired) != 0 {
+		t.Errorf("image matching only some prefixes should not be selected. got: %v", digests(got[0].Expired))
+	}
+}
+
+func digests(images []*ecr.Image) []string {
+	ds := []string{}
+
+	for _, image := range images {
+		ds = append(ds, image.Digest)
+	}
+
+	return ds
+}
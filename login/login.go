@@ -0,0 +1,126 @@
+package login
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/docker"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ToolDocker is Docker CLI
+	ToolDocker = "docker"
+	// ToolPodman is Podman
+	ToolPodman = "podman"
+	// ToolHelm is Helm, which logs in to OCI registries with `helm registry login`
+	ToolHelm = "helm"
+	// ToolBuildah is Buildah
+	ToolBuildah = "buildah"
+	// ToolSkopeo is Skopeo
+	ToolSkopeo = "skopeo"
+	// ToolCrictl is crictl, which pulls images through containerd CRI plugin
+	ToolCrictl = "crictl"
+)
+
+var (
+	// Tools is the list of supported tools
+	Tools = []string{
+		ToolDocker,
+		ToolPodman,
+		ToolHelm,
+		ToolBuildah,
+		ToolSkopeo,
+		ToolCrictl,
+	}
+)
+
+// Commands returns the login commands of the given tool for each credentials
+// Except for docker, commands pass the password via stdin, so they must be evaluated by shell (e.g. eval "$(ecrcli get-login --for podman)")
+func Commands(tool string, credentials []*ecr.Credentials) ([]string, error) {
+	cmds := []string{}
+
+	for _, creds := range credentials {
+		switch tool {
+		case ToolDocker:
+			cmds = append(cmds, creds.LoginCommand())
+		case ToolPodman, ToolBuildah, ToolSkopeo:
+			cmds = append(cmds, fmt.Sprintf("printf '%%s' %s | %s login --username %s --password-stdin %s", shellQuote(creds.Password), tool, shellQuote(creds.Username), host(creds.ProxyEndpoint)))
+		case ToolHelm:
+			cmds = append(cmds, fmt.Sprintf("printf '%%s' %s | helm registry login %s --username %s --password-stdin", shellQuote(creds.Password), host(creds.ProxyEndpoint), shellQuote(creds.Username)))
+		case ToolCrictl:
+			return nil, errors.New("crictl has no login command. print containerd registry config with auth file instead")
+		default:
+			return nil, errors.Errorf("tool must be one of %s. got: %q", strings.Join(Tools, ", "), tool)
+		}
+	}
+
+	return cmds, nil
+}
+
+// AuthFile returns the content of auth file of the given tool which contains all the given credentials
+// It is Docker config.json for docker, containers auth.json for podman, buildah and skopeo,
+// registry config.json for helm, and registry section of containerd config.toml for crictl
+func AuthFile(tool string, credentials []*ecr.Credentials) ([]byte, error) {
+	auths := []*docker.Auth{}
+
+	for _, creds := range credentials {
+		server := host(creds.ProxyEndpoint)
+		if tool == ToolDocker {
+			server = creds.ProxyEndpoint
+		}
+
+		auths = append(auths, &docker.Auth{
+			Server:   server,
+			Username: creds.Username,
+			Password: creds.Password,
+		})
+	}
+
+	switch tool {
+	case ToolDocker, ToolPodman, ToolHelm, ToolBuildah, ToolSkopeo:
+		data, err := docker.ConfigJSON(auths)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(data, '\n'), nil
+	case ToolCrictl:
+		return containerdConfig(auths), nil
+	default:
+		return nil, errors.Errorf("tool must be one of %s. got: %q", strings.Join(Tools, ", "), tool)
+	}
+}
+
+// containerdConfig returns registry auth configs for containerd CRI plugin
+func containerdConfig(auths []*docker.Auth) []byte {
+	var buf bytes.Buffer
+
+	for i, a := range auths {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buf, "[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.%q.auth]\n", a.Server)
+		fmt.Fprintf(&buf, "  username = %q\n", a.Username)
+		fmt.Fprintf(&buf, "  password = %q\n", a.Password)
+	}
+
+	return buf.Bytes()
+}
+
+// host returns the hostname of registry endpoint (e.g. https://012345678910.dkr.ecr.us-east-1.amazonaws.com)
+func host(endpoint string) string {
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+3:]
+	}
+
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// shellQuote quotes s with single quotes for POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,118 @@
+package login
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+)
+
+var credentials = []*ecr.Credentials{
+	&ecr.Credentials{
+		Username:      "AWS",
+		Password:      "password",
+		ProxyEndpoint: "https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+	},
+}
+
+func TestCommands(t *testing.T) {
+	testcases := []struct {
+		tool     string
+		expected []string
+		ok       bool
+	}{
+		{
+			tool: ToolDocker,
+			expected: []string{
+				"docker login -u AWS -p password https://012345678910.dkr.ecr.us-east-1.amazonaws.com",
+			},
+			ok: true,
+		},
+		{
+			tool: ToolPodman,
+			expected: []string{
+				"printf '%s' 'password' | podman login --username 'AWS' --password-stdin 012345678910.dkr.ecr.us-east-1.amazonaws.com",
+			},
+			ok: true,
+		},
+		{
+			tool: ToolHelm,
+			expected: []string{
+				"printf '%s' 'password' | helm registry login 012345678910.dkr.ecr.us-east-1.amazonaws.com --username 'AWS' --password-stdin",
+			},
+			ok: true,
+		},
+		{
+			tool: ToolCrictl,
+			ok:   false,
+		},
+		{
+			tool: "rkt",
+			ok:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := Commands(tc.tool, credentials)
+
+		if !tc.ok {
+			if err == nil {
+				t.Errorf("error should be raised for %q", tc.tool)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("error should not be raised for %q: %s", tc.tool, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("commands does not match for %q. expected: %q, got: %q", tc.tool, tc.expected, got)
+		}
+	}
+}
+
+func TestAuthFile(t *testing.T) {
+	testcases := []struct {
+		tool     string
+		expected string
+	}{
+		{
+			tool: ToolDocker,
+			expected: `{"auths":{"https://012345678910.dkr.ecr.us-east-1.amazonaws.com":{"auth":"QVdTOnBhc3N3b3Jk"}}}
+`,
+		},
+		{
+			tool: ToolSkopeo,
+			expected: `{"auths":{"012345678910.dkr.ecr.us-east-1.amazonaws.com":{"auth":"QVdTOnBhc3N3b3Jk"}}}
+`,
+		},
+		{
+			tool: ToolCrictl,
+			expected: `[plugins."io.containerd.grpc.v1.cri".registry.configs."012345678910.dkr.ecr.us-east-1.amazonaws.com".auth]
+  username = "AWS"
+  password = "password"
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := AuthFile(tc.tool, credentials)
+		if err != nil {
+			t.Errorf("error should not be raised for %q: %s", tc.tool, err)
+			continue
+		}
+
+		if string(got) != tc.expected {
+			t.Errorf("auth file does not match for %q. expected: %q, got: %q", tc.tool, tc.expected, string(got))
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got, expected := shellQuote("it's"), `'it'\''s'`; got != expected {
+		t.Errorf("quoted string does not match. expected: %q, got: %q", expected, got)
+	}
+}
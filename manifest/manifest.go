@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MediaTypeDockerManifest represents Docker image manifest schema2
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeDockerManifestList represents Docker manifest list
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// MediaTypeOCIManifest represents OCI image manifest
+	MediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeOCIIndex represents OCI image index
+	MediaTypeOCIIndex = "application/vnd.oci.image.index.v1+json"
+)
+
+// Manifest represents image manifest or manifest list
+type Manifest struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	MediaType     string        `json:"mediaType"`
+	Config        *Descriptor   `json:"config,omitempty"`
+	Layers        []*Descriptor `json:"layers,omitempty"`
+	Manifests     []*Descriptor `json:"manifests,omitempty"`
+}
+
+// Descriptor represents the reference to content
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+// Platform represents the platform which the image runs on
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	OSVersion    string `json:"os.version,omitempty"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// Parse decodes the given manifest
+// Docker schema2, OCI manifest, Docker manifest list and OCI index are supported
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to decode manifest")
+	}
+
+	if m.SchemaVersion != 2 {
+		return nil, errors.Errorf("unsupported manifest schema version %d", m.SchemaVersion)
+	}
+
+	// mediaType is optional in OCI manifests
+	if m.MediaType == "" {
+		if m.Manifests != nil {
+			m.MediaType = MediaTypeOCIIndex
+		} else {
+			m.MediaType = MediaTypeOCIManifest
+		}
+	}
+
+	switch m.MediaType {
+	case MediaTypeDockerManifest, MediaTypeOCIManifest:
+		if m.Config == nil {
+			return nil, errors.New("image manifest must have config")
+		}
+	case MediaTypeDockerManifestList, MediaTypeOCIIndex:
+	default:
+		return nil, errors.Errorf("unsupported media type %q", m.MediaType)
+	}
+
+	return &m, nil
+}
+
+// IsList returns whether the manifest is manifest list or image index
+func (m *Manifest) IsList() bool {
+	return m.MediaType == MediaTypeDockerManifestList || m.MediaType == MediaTypeOCIIndex
+}
+
+// LayersSize returns the sum of layer sizes in bytes
+func (m *Manifest) LayersSize() int64 {
+	var total int64
+
+	for _, layer := range m.Layers {
+		total += layer.Size
+	}
+
+	return total
+}
+
+func (p *Platform) String() string {
+	ss := []string{p.OS, p.Architecture}
+
+	if p.Variant != "" {
+		ss = append(ss, p.Variant)
+	}
+
+	s := strings.Join(ss, "/")
+
+	if p.OSVersion != "" {
+		s += " (" + p.OSVersion + ")"
+	}
+
+	return s
+}
@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		data       string
+		mediaType  string
+		layers     int
+		layersSize int64
+		manifests  int
+		isList     bool
+	}{
+		{
+			data: `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+  "config": {
+    "mediaType": "application/vnd.docker.container.image.v1+json",
+    "size": 1512,
+    "digest": "sha256:2b8fd9751c4c0f5dd266fcae00707e67a2545ef34f9a29354585f93dac906749"
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+      "size": 2065537,
+      "digest": "sha256:6d987f6f42797d81a318c40d442369ba3dc124883a0964d40b0c8f4f7561d913"
+    },
+    {
+      "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+      "size": 1034,
+      "digest": "sha256:b06dd7943a48e1b3ac5a527f0f835eafd3acccdbf508ae4179c1de77617f2310"
+    }
+  ]
+}`,
+			mediaType:  MediaTypeDockerManifest,
+			layers:     2,
+			layersSize: 2066571,
+		},
+		{
+			data: `{
+  "schemaVersion": 2,
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 1512,
+    "digest": "sha256:2b8fd9751c4c0f5dd266fcae00707e67a2545ef34f9a29354585f93dac906749"
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.oci.image.layer.v1.tar+gzip",
+      "size": 2065537,
+      "digest": "sha256:6d987f6f42797d81a318c40d442369ba3dc124883a0964d40b0c8f4f7561d913"
+    }
+  ]
+}`,
+			mediaType:  MediaTypeOCIManifest,
+			layers:     1,
+			layersSize: 2065537,
+		},
+		{
+			data: `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+  "manifests": [
+    {
+      "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+      "size": 528,
+      "digest": "sha256:6e6810e09a120ebcc3005741c228fecc7f77c513f6565c736370420fbc570bd8",
+      "platform": {
+        "architecture": "amd64",
+        "os": "linux"
+      }
+    },
+    {
+      "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+      "size": 528,
+      "digest": "sha256:96cfebabbfb81b9e6bf8d03e6d2e0de0a236d429e885a00c68a2a8e17da7cf93",
+      "platform": {
+        "architecture": "arm",
+        "os": "linux",
+        "variant": "v7"
+      }
+    }
+  ]
+}`,
+			mediaType: MediaTypeDockerManifestList,
+			manifests: 2,
+			isList:    true,
+		},
+		{
+			data: `{
+  "schemaVersion": 2,
+  "manifests": []
+}`,
+			mediaType: MediaTypeOCIIndex,
+			isList:    true,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := Parse([]byte(tc.data))
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if got.MediaType != tc.mediaType {
+			t.Errorf("media type does not match. expected: %q, got: %q", tc.mediaType, got.MediaType)
+		}
+
+		if len(got.Layers) != tc.layers {
+			t.Errorf("number of layers does not match. expected: %d, got: %d", tc.layers, len(got.Layers))
+		}
+
+		if got.LayersSize() != tc.layersSize {
+			t.Errorf("layers size does not match. expected: %d, got: %d", tc.layersSize, got.LayersSize())
+		}
+
+		if len(got.Manifests) != tc.manifests {
+			t.Errorf("number of manifests does not match. expected: %d, got: %d", tc.manifests, len(got.Manifests))
+		}
+
+		if got.IsList() != tc.isList {
+			t.Errorf("IsList does not match. expected: %t, got: %t", tc.isList, got.IsList())
+		}
+	}
+}
+
+func TestParse_unsupported(t *testing.T) {
+	testcases := []string{
+		`{"schemaVersion":1,"name":"foo","tag":"latest","fsLayers":[]}`,
+		`{"schemaVersion":2,"mediaType":"application/vnd.docker.plugin.v1+json"}`,
+		`{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`,
+		`not json`,
+	}
+
+	for _, tc := range testcases {
+		if _, err := Parse([]byte(tc)); err == nil {
+			t.Errorf("error should be raised for %s", tc)
+		}
+	}
+}
+
+func TestPlatformString(t *testing.T) {
+	testcases := []struct {
+		platform *Platform
+		expected string
+	}{
+		{
+			platform: &Platform{
+				OS:           "linux",
+				Architecture: "amd64",
+			},
+			expected: "linux/amd64",
+		},
+		{
+			platform: &Platform{
+				OS:           "linux",
+				Architecture: "arm",
+				Variant:      "v7",
+			},
+			expected: "linux/arm/v7",
+		},
+		{
+			platform: &Platform{
+				OS:           "windows",
+				Architecture: "amd64",
+				OSVersion:    "10.0.14393.1066",
+			},
+			expected: "windows/amd64 (10.0.14393.1066)",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.platform.String(); got != tc.expected {
+			t.Errorf("platform does not match. expected: %q, got: %q", tc.expected, got)
+		}
+	}
+}
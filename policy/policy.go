@@ -0,0 +1,337 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	policyVersions = []string{
+		"2012-10-17",
+		"2008-10-17",
+	}
+
+	documentKeys = []string{
+		"Version",
+		"Id",
+		"Statement",
+	}
+
+	statementKeys = []string{
+		"Sid",
+		"Effect",
+		"Principal",
+		"NotPrincipal",
+		"Action",
+		"NotAction",
+		"Resource",
+		"NotResource",
+		"Condition",
+	}
+)
+
+// Parse parses the given bytes as IAM policy document and validates it
+func Parse(data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "policy must be JSON object")
+	}
+
+	if err := Validate(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Validate checks whether the given document is well-formed IAM policy document
+func Validate(doc map[string]interface{}) error {
+	if err := checkKeys(doc, documentKeys, ""); err != nil {
+		return err
+	}
+
+	if v, ok := doc["Version"]; ok {
+		version, ok := v.(string)
+		if !ok || !contains(policyVersions, version) {
+			return errors.Errorf("Version must be one of %q. got: %v", policyVersions, v)
+		}
+	}
+
+	s, ok := doc["Statement"]
+	if !ok {
+		return errors.New("Statement is required")
+	}
+
+	var statements []interface{}
+
+	switch v := s.(type) {
+	case map[string]interface{}:
+		statements = []interface{}{v}
+	case []interface{}:
+		statements = v
+	default:
+		return errors.New("Statement must be object or array of objects")
+	}
+
+	if len(statements) == 0 {
+		return errors.New("Statement must not be empty")
+	}
+
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("Statement[%d] must be object", i)
+		}
+
+		if err := validateStatement(statement, fmt.Sprintf("Statement[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateStatement(statement map[string]interface{}, path string) error {
+	if err := checkKeys(statement, statementKeys, path+"."); err != nil {
+		return err
+	}
+
+	if v, ok := statement["Sid"]; ok {
+		if _, ok := v.(string); !ok {
+			return errors.Errorf("%s.Sid must be string", path)
+		}
+	}
+
+	switch statement["Effect"] {
+	case "Allow", "Deny":
+	default:
+		return errors.Errorf(`%s.Effect must be "Allow" or "Deny". got: %v`, path, statement["Effect"])
+	}
+
+	if err := checkExclusive(statement, "Principal", "NotPrincipal", path); err != nil {
+		return err
+	}
+
+	if err := checkExclusive(statement, "Action", "NotAction", path); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"Action", "NotAction", "Resource", "NotResource"} {
+		if v, ok := statement[key]; ok && !isStringOrStrings(v) {
+			return errors.Errorf("%s.%s must be string or array of strings", path, key)
+		}
+	}
+
+	for _, key := range []string{"Principal", "NotPrincipal"} {
+		v, ok := statement[key]
+		if !ok {
+			continue
+		}
+
+		switch p := v.(type) {
+		case string:
+			if p != "*" {
+				return errors.Errorf(`%s.%s must be "*" or object`, path, key)
+			}
+		case map[string]interface{}:
+			for k, ids := range p {
+				if !isStringOrStrings(ids) {
+					return errors.Errorf("%s.%s.%s must be string or array of strings", path, key, k)
+				}
+			}
+		default:
+			return errors.Errorf(`%s.%s must be "*" or object`, path, key)
+		}
+	}
+
+	if v, ok := statement["Condition"]; ok {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return errors.Errorf("%s.Condition must be object", path)
+		}
+	}
+
+	return nil
+}
+
+// Diff returns the structural differences from a to b line by line
+// Lines are prefixed by "-" for removed values and "+" for added values
+func Diff(a, b interface{}) []string {
+	lines := []string{}
+	diff(normalize(a), normalize(b), "", &lines)
+
+	return lines
+}
+
+// Format returns the indented JSON of the given document
+func Format(doc interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return "", errors.Wrap(err, "failed to encode policy")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func diff(a, b interface{}, path string, lines *[]string) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+
+	switch {
+	case a == nil:
+		*lines = append(*lines, fmt.Sprintf("+ %s: %s", displayPath(path), compact(b)))
+		return
+	case b == nil:
+		*lines = append(*lines, fmt.Sprintf("- %s: %s", displayPath(path), compact(a)))
+		return
+	}
+
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+
+	if aok && bok {
+		for _, k := range unionKeys(am, bm) {
+			diff(am[k], bm[k], path+"."+k, lines)
+		}
+
+		return
+	}
+
+	as, aok := a.([]interface{})
+	bs, bok := b.([]interface{})
+
+	if aok && bok {
+		n := len(as)
+		if len(bs) > n {
+			n = len(bs)
+		}
+
+		for i := 0; i < n; i++ {
+			var av, bv interface{}
+
+			if i < len(as) {
+				av = as[i]
+			}
+
+			if i < len(bs) {
+				bv = bs[i]
+			}
+
+			diff(av, bv, fmt.Sprintf("%s[%d]", path, i), lines)
+		}
+
+		return
+	}
+
+	*lines = append(*lines,
+		fmt.Sprintf("- %s: %s", displayPath(path), compact(a)),
+		fmt.Sprintf("+ %s: %s", displayPath(path), compact(b)),
+	)
+}
+
+func checkKeys(m map[string]interface{}, allowed []string, prefix string) error {
+	for k := range m {
+		if !contains(allowed, k) {
+			return errors.Errorf("unknown key %s%s", prefix, k)
+		}
+	}
+
+	return nil
+}
+
+func checkExclusive(statement map[string]interface{}, key, notKey, path string) error {
+	_, ok := statement[key]
+	_, notOk := statement[notKey]
+
+	switch {
+	case ok && notOk:
+		return errors.Errorf("%s must not have both %s and %s", path, key, notKey)
+	case !ok && !notOk:
+		return errors.Errorf("%s must have %s or %s", path, key, notKey)
+	}
+
+	return nil
+}
+
+func compact(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	return strings.TrimPrefix(path, ".")
+}
+
+func isStringOrStrings(v interface{}) bool {
+	switch vv := v.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, s := range vv {
+			if _, ok := s.(string); !ok {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// normalize converts nil map into untyped nil
+func normalize(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok && m == nil {
+		return nil
+	}
+
+	return v
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := []string{}
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		data string
+		ok   bool
+	}{
+		{
+			data: `{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::109876543210:root"]},"Action":["ecr:BatchGetImage","ecr:GetDownloadUrlForLayer"]}]}`,
+			ok:   true,
+		},
+		{
+			data: `{"Statement":{"Effect":"Deny","Principal":"*","NotAction":"ecr:*"}}`,
+			ok:   true,
+		},
+		{
+			data: `[]`,
+			ok:   false,
+		},
+		{
+			data: `{"Version":"2008-10-17"}`,
+			ok:   false,
+		},
+		{
+			data: `{"Version":"2017-01-01","Statement":[{"Effect":"Allow","Principal":"*","Action":"ecr:*"}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"Statement":[{"Effect":"allow","Principal":"*","Action":"ecr:*"}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"Statement":[{"Effect":"Allow","Action":"ecr:*"}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"ecr:*","NotAction":"ecr:PutImage"}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":[1]}]}`,
+			ok:   false,
+		},
+		{
+			data: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"ecr:*","Unknown":true}]}`,
+			ok:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := Parse([]byte(tc.data))
+
+		if tc.ok && err != nil {
+			t.Errorf("error should not be raised for %s. got: %s", tc.data, err)
+		}
+
+		if !tc.ok && err == nil {
+			t.Errorf("error should be raised for %s", tc.data)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a, err := Parse([]byte(`{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::109876543210:root"},"Action":"ecr:BatchGetImage"}]}`))
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	b, err := Parse([]byte(`{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::109876543210:root","arn:aws:iam::012345678910:root"]},"Action":"ecr:BatchGetImage"},{"Sid":"deny","Effect":"Deny","Principal":"*","Action":"ecr:DeleteRepository"}]}`))
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	expected := []string{
+		`- Statement[0].Principal.AWS: "arn:aws:iam::109876543210:root"`,
+		`+ Statement[0].Principal.AWS: ["arn:aws:iam::109876543210:root","arn:aws:iam::012345678910:root"]`,
+		`+ Statement[1]: {"Action":"ecr:DeleteRepository","Effect":"Deny","Principal":"*","Sid":"deny"}`,
+	}
+
+	if got := Diff(a, b); !reflect.DeepEqual(got, expected) {
+		t.Errorf("diff does not match. expected: %q, got: %q", expected, got)
+	}
+
+	if got := Diff(a, a); len(got) != 0 {
+		t.Errorf("diff should be empty. got: %q", got)
+	}
+
+	expected = []string{
+		`+ .: {"Statement":[{"Action":"ecr:BatchGetImage","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::109876543210:root"},"Sid":"pull"}],"Version":"2008-10-17"}`,
+	}
+
+	if got := Diff(nil, a); !reflect.DeepEqual(got, expected) {
+		t.Errorf("diff does not match. expected: %q, got: %q", expected, got)
+	}
+}
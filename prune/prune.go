@@ -0,0 +1,96 @@
+package prune
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+)
+
+// Rule represents the conditions of images to be pruned
+// Images are selected only if they satisfy all the given conditions
+type Rule struct {
+	// KeepLast is the number of the newest images kept among images matching UntaggedOnly, Include and Exclude
+	KeepLast int
+	// OlderThan selects images pushed before the duration ago. 0 means no restriction
+	OlderThan time.Duration
+	// UntaggedOnly selects untagged images only
+	UntaggedOnly bool
+	// Include selects images which have at least one tag matching the pattern
+	Include *regexp.Regexp
+	// Exclude keeps images which have at least one tag matching the pattern
+	Exclude *regexp.Regexp
+}
+
+// Select returns images to be pruned in order of PushedAt, newest first
+// Like imageCountMoreThan of ECR lifecycle policy, KeepLast counts only images matching tag conditions
+func Select(images []*ecr.Image, rule *Rule, now time.Time) []*ecr.Image {
+	sorted := make([]*ecr.Image, len(images))
+	copy(sorted, images)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PushedAt.After(sorted[j].PushedAt)
+	})
+
+	selected := []*ecr.Image{}
+	matched := 0
+
+	for _, image := range sorted {
+		if !rule.matchTags(image) {
+			continue
+		}
+
+		matched++
+
+		if matched <= rule.KeepLast {
+			continue
+		}
+
+		if rule.OlderThan > 0 && !image.PushedAt.Before(now.Add(-rule.OlderThan)) {
+			continue
+		}
+
+		selected = append(selected, image)
+	}
+
+	return selected
+}
+
+// TotalSize returns the sum of image sizes in bytes
+func TotalSize(images []*ecr.Image) int64 {
+	var total int64
+
+	for _, image := range images {
+		total += image.SizeInBytes
+	}
+
+	return total
+}
+
+// matchTags returns whether the image satisfies UntaggedOnly, Include and Exclude
+func (r *Rule) matchTags(image *ecr.Image) bool {
+	if r.UntaggedOnly && len(image.Tags) > 0 {
+		return false
+	}
+
+	if r.Include != nil && !matchAny(r.Include, image.Tags) {
+		return false
+	}
+
+	if r.Exclude != nil && matchAny(r.Exclude, image.Tags) {
+		return false
+	}
+
+	return true
+}
+
+func matchAny(re *regexp.Regexp, tags []string) bool {
+	for _, tag := range tags {
+		if re.MatchString(tag) {
+			return true
+		}
+	}
+
+	return false
+}
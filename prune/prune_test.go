@@ -0,0 +1,129 @@
+package prune
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+)
+
+func TestSelect(t *testing.T) {
+	now := time.Unix(1500532805, 0) // 2017-07-20 15:40:05 +0900
+
+	images := []*ecr.Image{
+		&ecr.Image{
+			Digest:   "sha256:1",
+			Tags:     []string{"latest", "release-3"},
+			PushedAt: now.Add(-1 * time.Hour),
+		},
+		&ecr.Image{
+			Digest:   "sha256:4",
+			Tags:     []string{},
+			PushedAt: now.Add(-96 * time.Hour),
+		},
+		&ecr.Image{
+			Digest:   "sha256:2",
+			Tags:     []string{"release-2"},
+			PushedAt: now.Add(-24 * time.Hour),
+		},
+		&ecr.Image{
+			Digest:   "sha256:3",
+			Tags:     []string{"pr-123"},
+			PushedAt: now.Add(-48 * time.Hour),
+		},
+		&ecr.Image{
+			Digest:   "sha256:5",
+			Tags:     []string{"release-1"},
+			PushedAt: now.Add(-120 * time.Hour),
+		},
+	}
+
+	testcases := []struct {
+		rule     *Rule
+		expected []string
+	}{
+		{
+			rule:     &Rule{},
+			expected: []string{"sha256:1", "sha256:2", "sha256:3", "sha256:4", "sha256:5"},
+		},
+		{
+			rule: &Rule{
+				KeepLast: 2,
+			},
+			expected: []string{"sha256:3", "sha256:4", "sha256:5"},
+		},
+		{
+			rule: &Rule{
+				OlderThan: 72 * time.Hour,
+			},
+			expected: []string{"sha256:4", "sha256:5"},
+		},
+		{
+			rule: &Rule{
+				UntaggedOnly: true,
+			},
+			expected: []string{"sha256:4"},
+		},
+		{
+			rule: &Rule{
+				Include: regexp.MustCompile(`^release-`),
+			},
+			expected: []string{"sha256:1", "sha256:2", "sha256:5"},
+		},
+		{
+			rule: &Rule{
+				KeepLast: 1,
+				Include:  regexp.MustCompile(`^release-`),
+				Exclude:  regexp.MustCompile(`^release-1$`),
+			},
+			expected: []string{"sha256:2"},
+		},
+		{
+			// the newest image does not match Include, so it is not counted in KeepLast
+			rule: &Rule{
+				KeepLast: 1,
+				Include:  regexp.MustCompile(`^pr-`),
+			},
+			expected: []string{},
+		},
+		{
+			rule: &Rule{
+				KeepLast:  1,
+				OlderThan: 72 * time.Hour,
+				Include:   regexp.MustCompile(`^release-`),
+			},
+			expected: []string{"sha256:5"},
+		},
+	}
+
+	for _, tc := range testcases {
+		got := []string{}
+
+		for _, image := range Select(images, tc.rule, now) {
+			got = append(got, image.Digest)
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("selected images do not match. rule: %#v, expected: %q, got: %q", tc.rule, tc.expected, got)
+		}
+	}
+}
+
+func TestTotalSize(t *testing.T) {
+	images := []*ecr.Image{
+		&ecr.Image{
+			SizeInBytes: 186629610,
+		},
+		&ecr.Image{
+			SizeInBytes: 178952648,
+		},
+	}
+
+	var expected int64 = 365582258
+
+	if got := TotalSize(images); got != expected {
+		t.Errorf("total size does not match. expected: %d, got: %d", expected, got)
+	}
+}
@@ -0,0 +1,325 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// OutputTable prints result as table
+	OutputTable = "table"
+	// OutputWide prints result as table with additional columns
+	OutputWide = "wide"
+	// OutputJSON prints result as JSON
+	OutputJSON = "json"
+	// OutputYAML prints result as YAML
+	OutputYAML = "yaml"
+)
+
+var (
+	// Outputs is the list of supported output formats
+	Outputs = []string{
+		OutputTable,
+		OutputWide,
+		OutputJSON,
+		OutputYAML,
+	}
+)
+
+// Column represents the column of table
+type Column struct {
+	Header string
+	// Wide column is printed only in wide output
+	Wide bool
+}
+
+// Table represents the tabular form of command result
+type Table struct {
+	Columns []*Column
+	Rows    [][]string
+	// Visible is the list of column headers to print. Default columns are printed if empty
+	Visible []string
+}
+
+// Options represents the options of Renderer
+type Options struct {
+	// Output is one of Outputs
+	Output string
+	// Format is Go template applied to each item of result. Format takes precedence over Output
+	Format string
+	// Query is JMESPath expression applied to result before rendering
+	Query string
+}
+
+// Renderer prints command results in the specified output format
+type Renderer struct {
+	w        io.Writer
+	output   string
+	template *template.Template
+	query    string
+}
+
+// New creates new Renderer object
+func New(w io.Writer, opts *Options) (*Renderer, error) {
+	valid := false
+
+	for _, o := range Outputs {
+		if opts.Output == o {
+			valid = true
+			break
+		}
+	}
+
+	if !valid {
+		return nil, errors.Errorf("output must be one of %s. got: %q", strings.Join(Outputs, ", "), opts.Output)
+	}
+
+	r := &Renderer{
+		w:      w,
+		output: opts.Output,
+		query:  opts.Query,
+	}
+
+	if opts.Query != "" {
+		if _, err := jmespath.NewParser().Parse(opts.Query); err != nil {
+			return nil, errors.Wrap(err, "failed to parse query")
+		}
+	}
+
+	if opts.Format != "" {
+		tmpl, err := parseTemplate(opts.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		r.template = tmpl
+	}
+
+	return r, nil
+}
+
+// Structured returns whether the result is printed in machine-readable format, template or query
+// Commands must pass the whole result to Render in structured output
+func (r *Renderer) Structured() bool {
+	return r.template != nil || r.query != "" || (r.output != OutputTable && r.output != OutputWide)
+}
+
+// Render prints v with template or in structured output, otherwise table
+// If query is given, it is applied to v beforehand and the result is printed in JSON instead of table
+func (r *Renderer) Render(v interface{}, table *Table) error {
+	if r.query != "" {
+		s, err := toStructured(v)
+		if err != nil {
+			return err
+		}
+
+		result, err := jmespath.Search(r.query, s)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply query")
+		}
+
+		v = convertNumbers(result)
+
+		if r.template == nil && r.output != OutputYAML {
+			return r.renderJSON(v)
+		}
+	}
+
+	if r.template != nil {
+		return r.renderTemplate(v)
+	}
+
+	switch r.output {
+	case OutputJSON:
+		return r.renderJSON(v)
+	case OutputYAML:
+		return r.renderYAML(v)
+	}
+
+	if table == nil {
+		return errors.Errorf("%s output is not supported", r.output)
+	}
+
+	w, err := r.NewTableWriter(table.Columns, table.Visible)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader()
+
+	for _, row := range table.Rows {
+		w.Write(row)
+	}
+
+	return w.Flush()
+}
+
+// NewTableWriter creates new TableWriter object to print table rows progressively
+// visible is the list of column headers to print in the given order, compared case-insensitively
+// Default columns are printed if visible is empty
+func (r *Renderer) NewTableWriter(columns []*Column, visible []string) (*TableWriter, error) {
+	indices := []int{}
+
+	if len(visible) == 0 {
+		for i, c := range columns {
+			if c.Wide && r.output != OutputWide {
+				continue
+			}
+
+			indices = append(indices, i)
+		}
+	} else {
+		for _, name := range visible {
+			found := false
+
+			for i, c := range columns {
+				if strings.EqualFold(c.Header, strings.TrimSpace(name)) {
+					indices = append(indices, i)
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				headers := []string{}
+
+				for _, c := range columns {
+					headers = append(headers, strings.ToLower(c.Header))
+				}
+
+				return nil, errors.Errorf("column must be one of %s. got: %q", strings.Join(headers, ", "), name)
+			}
+		}
+	}
+
+	return &TableWriter{
+		tw:      tabwriter.NewWriter(r.w, 0, 0, 2, ' ', 0),
+		columns: columns,
+		indices: indices,
+	}, nil
+}
+
+func (r *Renderer) renderJSON(v interface{}) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return errors.Wrap(err, "failed to encode result as JSON")
+	}
+
+	_, err := r.w.Write(buf.Bytes())
+
+	return err
+}
+
+func (r *Renderer) renderYAML(v interface{}) error {
+	// convert via JSON so that field names and value formats are the same as JSON output
+	s, err := toStructured(v)
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(convertNumbers(s))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode result as YAML")
+	}
+
+	_, err = r.w.Write(b)
+
+	return err
+}
+
+// TableWriter prints table rows with aligned columns
+type TableWriter struct {
+	tw      *tabwriter.Writer
+	columns []*Column
+	indices []int
+}
+
+// WriteHeader writes the header row
+func (w *TableWriter) WriteHeader() {
+	headers := make([]string, len(w.columns))
+
+	for i, c := range w.columns {
+		headers[i] = c.Header
+	}
+
+	w.Write(headers)
+}
+
+// Write writes the row. Values of invisible columns are dropped
+func (w *TableWriter) Write(row []string) {
+	values := []string{}
+
+	for _, i := range w.indices {
+		if i < len(row) {
+			values = append(values, row[i])
+		} else {
+			values = append(values, "")
+		}
+	}
+
+	fmt.Fprintln(w.tw, strings.Join(values, "\t"))
+}
+
+// Flush prints buffered rows
+func (w *TableWriter) Flush() error {
+	return w.tw.Flush()
+}
+
+// toStructured converts v into the combination of maps, slices and scalar values through JSON
+// Numbers are converted into float64 as JMESPath requires
+func toStructured(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode result")
+	}
+
+	var s interface{}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, errors.Wrap(err, "failed to decode result")
+	}
+
+	return s, nil
+}
+
+// convertNumbers converts integral float64 into int64 so that they are not printed in exponent notation
+func convertNumbers(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			vv[k] = convertNumbers(e)
+		}
+
+		return vv
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = convertNumbers(e)
+		}
+
+		return vv
+	case float64:
+		if vv == math.Trunc(vv) && math.Abs(vv) < 1<<53 {
+			return int64(vv)
+		}
+
+		return vv
+	default:
+		return v
+	}
+}
@@ -0,0 +1,301 @@
+package renderer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type item struct {
+	Name      string    `json:"Name"`
+	Size      int64     `json:"Size"`
+	Tags      []string  `json:"Tags"`
+	CreatedAt time.Time `json:"CreatedAt"`
+}
+
+var (
+	items = []*item{
+		&item{
+			Name:      "foo",
+			Size:      186629610,
+			Tags:      []string{"latest"},
+			CreatedAt: time.Date(2017, 7, 20, 6, 40, 5, 0, time.UTC),
+		},
+		&item{
+			Name:      "bar",
+			Size:      178952648,
+			Tags:      []string{},
+			CreatedAt: time.Date(2017, 7, 20, 6, 40, 5, 0, time.UTC),
+		},
+	}
+
+	table = &Table{
+		Columns: []*Column{
+			&Column{
+				Header: "NAME",
+			},
+			&Column{
+				Header: "SIZE",
+				Wide:   true,
+			},
+			&Column{
+				Header: "TAGS",
+			},
+		},
+		Rows: [][]string{
+			[]string{"foo", "186629610", "latest"},
+			[]string{"bar", "178952648", ""},
+		},
+	}
+)
+
+func TestNew_invalid(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, &Options{
+		Output: "xml",
+	}); err == nil {
+		t.Errorf("error should be raised")
+	}
+
+	if _, err := New(&bytes.Buffer{}, &Options{
+		Output: OutputTable,
+		Format: "{{.Name",
+	}); err == nil {
+		t.Errorf("error should be raised")
+	}
+
+	if _, err := New(&bytes.Buffer{}, &Options{
+		Output: OutputTable,
+		Query:  "[?",
+	}); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
+
+func TestRender(t *testing.T) {
+	testcases := []struct {
+		output     string
+		structured bool
+		expected   string
+	}{
+		{
+			output:     OutputTable,
+			structured: false,
+			expected: `NAME  TAGS
+foo   latest
+bar   
+`,
+		},
+		{
+			output:     OutputWide,
+			structured: false,
+			expected: `NAME  SIZE       TAGS
+foo   186629610  latest
+bar   178952648  
+`,
+		},
+		{
+			output:     OutputJSON,
+			structured: true,
+			expected: `[
+  {
+    "Name": "foo",
+    "Size": 186629610,
+    "Tags": [
+      "latest"
+    ],
+    "CreatedAt": "2017-07-20T06:40:05Z"
+  },
+  {
+    "Name": "bar",
+    "Size": 178952648,
+    "Tags": [],
+    "CreatedAt": "2017-07-20T06:40:05Z"
+  }
+]
+`,
+		},
+		{
+			output:     OutputYAML,
+			structured: true,
+			expected: `- CreatedAt: 2017-07-20T06:40:05Z
+  Name: foo
+  Size: 186629610
+  Tags:
+  - latest
+- CreatedAt: 2017-07-20T06:40:05Z
+  Name: bar
+  Size: 178952648
+  Tags: []
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+
+		r, err := New(&buf, &Options{
+			Output: tc.output,
+		})
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if r.Structured() != tc.structured {
+			t.Errorf("Structured() of %s does not match. expected: %t, got: %t", tc.output, tc.structured, r.Structured())
+		}
+
+		if err := r.Render(items, table); err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if got := buf.String(); got != tc.expected {
+			t.Errorf("%s output does not match. expected:\n%s\ngot:\n%s", tc.output, tc.expected, got)
+		}
+	}
+}
+
+func TestRender_visible(t *testing.T) {
+	var buf bytes.Buffer
+
+	r, err := New(&buf, &Options{
+		Output: OutputTable,
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	if err := r.Render(items, &Table{
+		Columns: table.Columns,
+		Rows:    table.Rows,
+		Visible: []string{"tags", "Size"},
+	}); err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	expected := `TAGS    SIZE
+latest  186629610
+        178952648
+`
+
+	if got := buf.String(); got != expected {
+		t.Errorf("output does not match. expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if err := r.Render(items, &Table{
+		Columns: table.Columns,
+		Rows:    table.Rows,
+		Visible: []string{"unknown"},
+	}); err == nil {
+		t.Errorf("error should be raised")
+	}
+}
+
+func TestRender_template(t *testing.T) {
+	testcases := []struct {
+		format   string
+		v        interface{}
+		expected string
+	}{
+		{
+			format: `{{.Name}} {{join .Tags ","}} {{humanSize .Size}}`,
+			v:      items,
+			expected: `foo latest 178.0 MiB
+bar  170.7 MiB
+`,
+		},
+		{
+			format:   `{{json .Tags}}`,
+			v:        items[0],
+			expected: "[\"latest\"]\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+
+		r, err := New(&buf, &Options{
+			Output: OutputTable,
+			Format: tc.format,
+		})
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if !r.Structured() {
+			t.Errorf("Structured() should be true with template")
+		}
+
+		if err := r.Render(tc.v, table); err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if got := buf.String(); got != tc.expected {
+			t.Errorf("output does not match. expected:\n%s\ngot:\n%s", tc.expected, got)
+		}
+	}
+}
+
+func TestRender_query(t *testing.T) {
+	testcases := []struct {
+		output   string
+		format   string
+		query    string
+		expected string
+	}{
+		{
+			output: OutputTable,
+			query:  "[?Size > `180000000`].Name",
+			expected: `[
+  "foo"
+]
+`,
+		},
+		{
+			output: OutputYAML,
+			query:  "[].{name: Name, size: Size}",
+			expected: `- name: foo
+  size: 186629610
+- name: bar
+  size: 178952648
+`,
+		},
+		{
+			output: OutputTable,
+			format: "{{.Name}}: {{.Size}}",
+			query:  "[?length(Tags) == `0`]",
+			expected: `bar: 178952648
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+
+		r, err := New(&buf, &Options{
+			Output: tc.output,
+			Format: tc.format,
+			Query:  tc.query,
+		})
+		if err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if !r.Structured() {
+			t.Errorf("Structured() should be true with query")
+		}
+
+		if err := r.Render(items, table); err != nil {
+			t.Errorf("got error: %s", err)
+			continue
+		}
+
+		if got := buf.String(); got != tc.expected {
+			t.Errorf("output does not match. expected:\n%s\ngot:\n%s", tc.expected, got)
+		}
+	}
+}
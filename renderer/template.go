@@ -0,0 +1,66 @@
+package renderer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dtan4/ecrcli/format"
+	"github.com/pkg/errors"
+)
+
+var (
+	templateFuncs = template.FuncMap{
+		"join":      strings.Join,
+		"humanSize": format.Size,
+		"since": func(t time.Time) string {
+			return format.Relative(t, time.Now())
+		},
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+
+			return string(b), nil
+		},
+	}
+)
+
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse format template")
+	}
+
+	return tmpl, nil
+}
+
+// renderTemplate executes template against each item if v is slice, otherwise against v itself
+func (r *Renderer) renderTemplate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Slice {
+		return r.executeTemplate(v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := r.executeTemplate(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) executeTemplate(v interface{}) error {
+	if err := r.template.Execute(r.w, v); err != nil {
+		return errors.Wrap(err, "failed to execute format template")
+	}
+
+	_, err := r.w.Write([]byte("\n"))
+
+	return err
+}
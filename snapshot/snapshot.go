@@ -0,0 +1,281 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/pkg/errors"
+)
+
+// Snapshot represents the inventory of repositories and images at a point in time
+type Snapshot struct {
+	CreatedAt    time.Time     `json:"CreatedAt"`
+	Repositories []*Repository `json:"Repositories"`
+}
+
+// Repository represents the repository in snapshot
+type Repository struct {
+	Name   string   `json:"Name"`
+	Images []*Image `json:"Images"`
+}
+
+// Image represents the image in snapshot
+type Image struct {
+	Digest      string    `json:"Digest"`
+	Tags        []string  `json:"Tags"`
+	SizeInBytes int64     `json:"SizeInBytes"`
+	PushedAt    time.Time `json:"PushedAt"`
+}
+
+// ImageChange represents the image added to or removed from repository
+type ImageChange struct {
+	Repository string   `json:"Repository"`
+	Digest     string   `json:"Digest"`
+	Tags       []string `json:"Tags"`
+}
+
+// TagMove represents the tag which points to another digest
+type TagMove struct {
+	Repository string `json:"Repository"`
+	Tag        string `json:"Tag"`
+	From       string `json:"From"`
+	To         string `json:"To"`
+}
+
+// Diff represents the differences between two snapshots
+type Diff struct {
+	AddedRepositories   []string       `json:"AddedRepositories"`
+	RemovedRepositories []string       `json:"RemovedRepositories"`
+	AddedImages         []*ImageChange `json:"AddedImages"`
+	RemovedImages       []*ImageChange `json:"RemovedImages"`
+	MovedTags           []*TagMove     `json:"MovedTags"`
+}
+
+// New creates new empty Snapshot
+func New(createdAt time.Time) *Snapshot {
+	return &Snapshot{
+		CreatedAt:    createdAt,
+		Repositories: []*Repository{},
+	}
+}
+
+// Parse parses the given bytes as snapshot JSON and validates it
+func Parse(data []byte) (*Snapshot, error) {
+	var s Snapshot
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrap(err, "failed to parse snapshot")
+	}
+
+	// reject other JSON files, which would be read as empty snapshot
+	if s.Repositories == nil {
+		return nil, errors.New("Repositories must be given")
+	}
+
+	if s.CreatedAt.IsZero() {
+		return nil, errors.New("CreatedAt must be given")
+	}
+
+	for i, repo := range s.Repositories {
+		if repo == nil {
+			return nil, errors.Errorf("Repositories[%d] must be an object", i)
+		}
+
+		if repo.Name == "" {
+			return nil, errors.Errorf("Repositories[%d]: Name must be given", i)
+		}
+
+		for j, image := range repo.Images {
+			if image == nil {
+				return nil, errors.Errorf("Repositories[%d].Images[%d] must be an object", i, j)
+			}
+		}
+	}
+
+	return &s, nil
+}
+
+// AddRepository adds the repository with its images
+// Repositories are kept in order of name, and images in order of digest
+func (s *Snapshot) AddRepository(name string, images []*ecr.Image) {
+	repo := &Repository{
+		Name:   name,
+		Images: []*Image{},
+	}
+
+	for _, image := range images {
+		tags := make([]string, len(image.Tags))
+		copy(tags, image.Tags)
+		sort.Strings(tags)
+
+		repo.Images = append(repo.Images, &Image{
+			Digest:      image.Digest,
+			Tags:        tags,
+			SizeInBytes: image.SizeInBytes,
+			PushedAt:    image.PushedAt,
+		})
+	}
+
+	sort.Slice(repo.Images, func(i, j int) bool {
+		return repo.Images[i].Digest < repo.Images[j].Digest
+	})
+
+	s.Repositories = append(s.Repositories, repo)
+
+	sort.SliceStable(s.Repositories, func(i, j int) bool {
+		return s.Repositories[i].Name < s.Repositories[j].Name
+	})
+}
+
+// ImageCount returns the number of images in all repositories
+func (s *Snapshot) ImageCount() int {
+	count := 0
+
+	for _, repo := range s.Repositories {
+		count += len(repo.Images)
+	}
+
+	return count
+}
+
+// Marshal returns the indented JSON of snapshot
+func (s *Snapshot) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode snapshot")
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Compare returns the differences from snapshot a to b
+// Images of added or removed repositories are also reported as added or removed images
+func Compare(a, b *Snapshot) *Diff {
+	d := &Diff{
+		AddedRepositories:   []string{},
+		RemovedRepositories: []string{},
+		AddedImages:         []*ImageChange{},
+		RemovedImages:       []*ImageChange{},
+		MovedTags:           []*TagMove{},
+	}
+
+	reposA, reposB := repositoryMap(a), repositoryMap(b)
+
+	for _, name := range unionNames(reposA, reposB) {
+		repoA, okA := reposA[name]
+		repoB, okB := reposB[name]
+
+		if !okA {
+			d.AddedRepositories = append(d.AddedRepositories, name)
+			repoA = &Repository{Name: name}
+		}
+
+		if !okB {
+			d.RemovedRepositories = append(d.RemovedRepositories, name)
+			repoB = &Repository{Name: name}
+		}
+
+		d.compareImages(repoA, repoB)
+	}
+
+	return d
+}
+
+// Empty returns whether there is no difference
+func (d *Diff) Empty() bool {
+	return len(d.AddedRepositories) == 0 && len(d.RemovedRepositories) == 0 &&
+		len(d.AddedImages) == 0 && len(d.RemovedImages) == 0 && len(d.MovedTags) == 0
+}
+
+func (d *Diff) compareImages(a, b *Repository) {
+	imagesA, imagesB := map[string]*Image{}, map[string]*Image{}
+	tagsA, tagsB := map[string]string{}, map[string]string{}
+
+	for _, image := range a.Images {
+		imagesA[image.Digest] = image
+
+		for _, tag := range image.Tags {
+			tagsA[tag] = image.Digest
+		}
+	}
+
+	for _, image := range b.Images {
+		imagesB[image.Digest] = image
+
+		for _, tag := range image.Tags {
+			tagsB[tag] = image.Digest
+		}
+	}
+
+	for _, image := range b.Images {
+		if _, ok := imagesA[image.Digest]; !ok {
+			d.AddedImages = append(d.AddedImages, &ImageChange{
+				Repository: b.Name,
+				Digest:     image.Digest,
+				Tags:       image.Tags,
+			})
+		}
+	}
+
+	for _, image := range a.Images {
+		if _, ok := imagesB[image.Digest]; !ok {
+			d.RemovedImages = append(d.RemovedImages, &ImageChange{
+				Repository: a.Name,
+				Digest:     image.Digest,
+				Tags:       image.Tags,
+			})
+		}
+	}
+
+	tags := []string{}
+
+	for tag := range tagsA {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		to, ok := tagsB[tag]
+		if !ok || to == tagsA[tag] {
+			continue
+		}
+
+		d.MovedTags = append(d.MovedTags, &TagMove{
+			Repository: a.Name,
+			Tag:        tag,
+			From:       tagsA[tag],
+			To:         to,
+		})
+	}
+}
+
+func repositoryMap(s *Snapshot) map[string]*Repository {
+	m := map[string]*Repository{}
+
+	for _, repo := range s.Repositories {
+		m[repo.Name] = repo
+	}
+
+	return m
+}
+
+func unionNames(a, b map[string]*Repository) []string {
+	names := []string{}
+
+	for name := range a {
+		names = append(names, name)
+	}
+
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+)
+
+func TestSnapshot(t *testing.T) {
+	createdAt := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+	pushedAt := time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	s := New(createdAt)
+	s.AddRepository("foo", []*ecr.Image{
+		&ecr.Image{Repository: "foo", Digest: "sha256:bbb", Tags: []string{"v2", "latest"}, SizeInBytes: 100, PushedAt: pushedAt},
+		&ecr.Image{Repository: "foo", Digest: "sha256:aaa", Tags: []string{}, SizeInBytes: 200, PushedAt: pushedAt},
+	})
+	s.AddRepository("bar", []*ecr.Image{})
+
+	expected := &Snapshot{
+		CreatedAt: createdAt,
+		Repositories: []*Repository{
+			&Repository{
+				Name:   "bar",
+				Images: []*Image{},
+			},
+			&Repository{
+				Name: "foo",
+				Images: []*Image{
+					&Image{Digest: "sha256:aaa", Tags: []string{}, SizeInBytes: 200, PushedAt: pushedAt},
+					&Image{Digest: "sha256:bbb", Tags: []string{"latest", "v2"}, SizeInBytes: 100, PushedAt: pushedAt},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("snapshot does not match. expected: %#v, got: %#v", expected, s)
+	}
+
+	if s.ImageCount() != 2 {
+		t.Errorf("image count does not match. expected: 2, got: %d", s.ImageCount())
+	}
+
+	data, err := s.Marshal()
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("snapshot does not match after roundtrip. expected: %#v, got: %#v", expected, got)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	testcases := []string{
+		`[]`,
+		`{}`,
+		`{"rules":[{"rulePriority":1}]}`,
+		`{"CreatedAt":"2017-08-01T00:00:00Z"}`,
+		`{"CreatedAt":"2017-08-01T00:00:00Z","Repositories":null}`,
+		`{"Repositories":[]}`,
+		`{"CreatedAt":"2017-08-01T00:00:00Z","Repositories":[null]}`,
+		`{"CreatedAt":"2017-08-01T00:00:00Z","Repositories":[{"Images":[]}]}`,
+		`{"CreatedAt":"2017-08-01T00:00:00Z","Repositories":[{"Name":"app","Images":[null]}]}`,
+	}
+
+	for _, tc := range testcases {
+		if _, err := Parse([]byte(tc)); err == nil {
+			t.Errorf("error should be raised for %s", tc)
+		}
+	}
+
+	if _, err := Parse([]byte(`{"CreatedAt":"2017-08-01T00:00:00Z","Repositories":[]}`)); err != nil {
+		t.Errorf("error should not be raised for empty registry: %s", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := &Snapshot{
+		Repositories: []*Repository{
+			&Repository{
+				Name: "app",
+				Images: []*Image{
+					&Image{Digest: "sha256:aaa", Tags: []string{"v1"}},
+					&Image{Digest: "sha256:bbb", Tags: []string{"latest", "v2"}},
+				},
+			},
+			&Repository{
+				Name: "old",
+				Images: []*Image{
+					&Image{Digest: "sha256:ooo", Tags: []string{"latest"}},
+				},
+			},
+		},
+	}
+
+	b := &Snapshot{
+		Repositories: []*Repository{
+			&Repository{
+				Name: "app",
+				Images: []*Image{
+					&Image{Digest: "sha256:bbb", Tags: []string{"v2"}},
+					&Image{Digest: "sha256:ccc", Tags: []string{"latest", "v3"}},
+				},
+			},
+			&Repository{
+				Name: "new",
+				Images: []*Image{
+					&Image{Digest: "sha256:nnn", Tags: []string{"latest"}},
+				},
+			},
+		},
+	}
+
+	expected := &Diff{
+		AddedRepositories:   []string{"new"},
+		RemovedRepositories: []string{"old"},
+		AddedImages: []*ImageChange{
+			&ImageChange{Repository: "app", Digest: "sha256:ccc", Tags: []string{"latest", "v3"}},
+			&ImageChange{Repository: "new", Digest: "sha256:nnn", Tags: []string{"latest"}},
+		},
+		RemovedImages: []*ImageChange{
+			&ImageChange{Repository: "app", Digest: "sha256:aaa", Tags: []string{"v1"}},
+			&ImageChange{Repository: "old", Digest: "sha256:ooo", Tags: []string{"latest"}},
+		},
+		MovedTags: []*TagMove{
+			&TagMove{Repository: "app", Tag: "latest", From: "sha256:bbb", To: "sha256:ccc"},
+		},
+	}
+
+	got := Compare(a, b)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("diff does not match. expected: %#v, got: %#v", expected, got)
+	}
+
+	if got.Empty() {
+		t.Errorf("diff should not be empty")
+	}
+
+	if !Compare(a, a).Empty() {
+		t.Errorf("diff of the same snapshot should be empty")
+	}
+}
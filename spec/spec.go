@@ -0,0 +1,256 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dtan4/ecrcli/policy"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ActionCreate creates repository, and sets its policy if given
+	ActionCreate = "create"
+	// ActionUpdate sets or deletes repository policy
+	ActionUpdate = "update"
+	// ActionDelete deletes repository
+	ActionDelete = "delete"
+)
+
+// Spec represents the desired state of repositories
+type Spec struct {
+	Repositories []*Repository `yaml:"repositories" json:"repositories"`
+}
+
+// Repository represents the desired state of repository
+// Repository has no policy if Policy is nil
+type Repository struct {
+	Name   string                 `yaml:"name" json:"name"`
+	Policy map[string]interface{} `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// State represents the current state of repositories, which maps repository name to its policy
+// Policy is nil if the repository has no policy
+type State map[string]map[string]interface{}
+
+// Change represents the change to make the current state into the desired state
+type Change struct {
+	Action     string `json:"Action"`
+	Repository string `json:"Repository"`
+	// Policy is the desired policy. nil means the policy is deleted
+	Policy map[string]interface{} `json:"Policy"`
+	// Diff is the difference of policy from the current one
+	Diff []string `json:"Diff"`
+}
+
+// String returns the summary of change (e.g. "create foo")
+func (c *Change) String() string {
+	if c.Action == ActionUpdate && c.Policy == nil {
+		return fmt.Sprintf("%s %s (delete policy)", c.Action, c.Repository)
+	}
+
+	return fmt.Sprintf("%s %s", c.Action, c.Repository)
+}
+
+// Parse parses the given bytes as YAML spec and validates it
+func Parse(data []byte) (*Spec, error) {
+	var raw struct {
+		Repositories []struct {
+			Name   string      `yaml:"name"`
+			Policy interface{} `yaml:"policy"`
+		} `yaml:"repositories"`
+	}
+
+	// reject unknown keys, otherwise a typo such as "polcy" would be planned as policy deletion
+	if err := yaml.UnmarshalStrict(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to parse spec")
+	}
+
+	// empty or truncated input must not be planned as deletion of all repositories
+	if raw.Repositories == nil {
+		return nil, errors.New("repositories must be given")
+	}
+
+	s := &Spec{
+		Repositories: []*Repository{},
+	}
+	names := map[string]bool{}
+
+	for i, r := range raw.Repositories {
+		if r.Name == "" {
+			return nil, errors.Errorf("repositories[%d]: name must be given", i)
+		}
+
+		if names[r.Name] {
+			return nil, errors.Errorf("repositories[%d]: repository %s is defined twice", i, r.Name)
+		}
+
+		names[r.Name] = true
+
+		repo := &Repository{
+			Name: r.Name,
+		}
+
+		if r.Policy != nil {
+			doc, err := parsePolicy(r.Policy)
+			if err != nil {
+				return nil, errors.Wrapf(err, "repositories[%d]: invalid policy of %s", i, r.Name)
+			}
+
+			repo.Policy = doc
+		}
+
+		s.Repositories = append(s.Repositories, repo)
+	}
+
+	return s, nil
+}
+
+// FromState returns the spec of the given state, in order of repository name
+func FromState(state State) *Spec {
+	s := &Spec{
+		Repositories: []*Repository{},
+	}
+
+	for name, doc := range state {
+		s.Repositories = append(s.Repositories, &Repository{
+			Name:   name,
+			Policy: doc,
+		})
+	}
+
+	sort.Slice(s.Repositories, func(i, j int) bool {
+		return s.Repositories[i].Name < s.Repositories[j].Name
+	})
+
+	return s
+}
+
+// Marshal returns the YAML representation of spec
+func (s *Spec) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode spec")
+	}
+
+	return data, nil
+}
+
+// Plan returns the changes to make the current state into the desired state
+// Changes are ordered by action (create, update, delete), then by repository name
+func Plan(s *Spec, current State) []*Change {
+	creates, updates, deletes := []*Change{}, []*Change{}, []*Change{}
+	desired := map[string]bool{}
+
+	for _, repo := range s.Repositories {
+		desired[repo.Name] = true
+
+		currentPolicy, ok := current[repo.Name]
+		if !ok {
+			creates = append(creates, &Change{
+				Action:     ActionCreate,
+				Repository: repo.Name,
+				Policy:     repo.Policy,
+				Diff:       policy.Diff(nil, repo.Policy),
+			})
+
+			continue
+		}
+
+		if lines := policy.Diff(currentPolicy, repo.Policy); len(lines) > 0 {
+			updates = append(updates, &Change{
+				Action:     ActionUpdate,
+				Repository: repo.Name,
+				Policy:     repo.Policy,
+				Diff:       lines,
+			})
+		}
+	}
+
+	for name := range current {
+		if desired[name] {
+			continue
+		}
+
+		deletes = append(deletes, &Change{
+			Action:     ActionDelete,
+			Repository: name,
+			Diff:       []string{},
+		})
+	}
+
+	changes := []*Change{}
+
+	for _, cs := range [][]*Change{creates, updates, deletes} {
+		sort.SliceStable(cs, func(i, j int) bool {
+			return cs[i].Repository < cs[j].Repository
+		})
+
+		changes = append(changes, cs...)
+	}
+
+	return changes
+}
+
+// parsePolicy converts policy decoded from YAML into JSON-compatible document and validates it
+func parsePolicy(v interface{}) (map[string]interface{}, error) {
+	var data []byte
+
+	if s, ok := v.(string); ok {
+		// policy written as JSON string
+		data = []byte(s)
+	} else {
+		converted, err := convertKeys(v)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = json.Marshal(converted)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode policy")
+		}
+	}
+
+	return policy.Parse(data)
+}
+
+// convertKeys converts map[interface{}]interface{} decoded by yaml.v2 into map[string]interface{} recursively
+func convertKeys(v interface{}) (interface{}, error) {
+	switch vv := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+
+		for k, value := range vv {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, errors.Errorf("key must be string. got: %v", k)
+			}
+
+			converted, err := convertKeys(value)
+			if err != nil {
+				return nil, err
+			}
+
+			m[ks] = converted
+		}
+
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, 0, len(vv))
+
+		for _, value := range vv {
+			converted, err := convertKeys(value)
+			if err != nil {
+				return nil, err
+			}
+
+			s = append(s, converted)
+		}
+
+		return s, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,239 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testPolicy = map[string]interface{}{
+	"Version": "2008-10-17",
+	"Statement": []interface{}{
+		map[string]interface{}{
+			"Sid":       "pull",
+			"Effect":    "Allow",
+			"Principal": map[string]interface{}{"AWS": "arn:aws:iam::109876543210:root"},
+			"Action":    []interface{}{"ecr:BatchGetImage", "ecr:GetDownloadUrlForLayer"},
+		},
+	},
+}
+
+func TestParse(t *testing.T) {
+	data := `repositories:
+  - name: foo
+    policy:
+      Version: "2008-10-17"
+      Statement:
+        - Sid: pull
+          Effect: Allow
+          Principal:
+            AWS: arn:aws:iam::109876543210:root
+          Action:
+            - ecr:BatchGetImage
+            - ecr:GetDownloadUrlForLayer
+  - name: bar
+    policy: '{"Version":"2008-10-17","Statement":[{"Sid":"pull","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::109876543210:root"},"Action":["ecr:BatchGetImage","ecr:GetDownloadUrlForLayer"]}]}'
+  - name: baz
+`
+
+	expected := &Spec{
+		Repositories: []*Repository{
+			&Repository{
+				Name:   "foo",
+				Policy: testPolicy,
+			},
+			&Repository{
+				Name:   "bar",
+				Policy: testPolicy,
+			},
+			&Repository{
+				Name: "baz",
+			},
+		},
+	}
+
+	got, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("spec does not match. expected: %#v, got: %#v", expected, got)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	testcases := []string{
+		`repositories: foo`,
+		`repositories: [{policy: {Version: "2008-10-17"}}]`,
+		`repositories: [{name: foo}, {name: foo}]`,
+		`repositories: [{name: foo, policy: {Version: "2008-10-17"}}]`,
+		`repositories: [{name: foo, polcy: {Version: "2008-10-17"}}]`,
+		`repository: [{name: foo}]`,
+		``,
+		"# generated by script\n",
+		`repositories:`,
+		`repositories: null`,
+	}
+
+	for _, tc := range testcases {
+		if _, err := Parse([]byte(tc)); err == nil {
+			t.Errorf("error should be raised for %q", tc)
+		}
+	}
+}
+
+func TestFromState(t *testing.T) {
+	state := State{
+		"foo": testPolicy,
+		"bar": nil,
+	}
+
+	expected := &Spec{
+		Repositories: []*Repository{
+			&Repository{
+				Name: "bar",
+			},
+			&Repository{
+				Name:   "foo",
+				Policy: testPolicy,
+			},
+		},
+	}
+
+	got := FromState(state)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("spec does not match. expected: %#v, got: %#v", expected, got)
+	}
+
+	if changes := Plan(got, state); len(changes) != 0 {
+		t.Errorf("spec from state should have no changes. got: %v", changes)
+	}
+}
+
+func TestParse_emptyList(t *testing.T) {
+	got, err := Parse([]byte(`repositories: []`))
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	if len(got.Repositories) != 0 {
+		t.Errorf("spec should have no repositories. got: %d", len(got.Repositories))
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	s := &Spec{
+		Repositories: []*Repository{
+			&Repository{
+				Name:   "foo",
+				Policy: testPolicy,
+			},
+			&Repository{
+				Name: "bar",
+			},
+		},
+	}
+
+	data, err := s.Marshal()
+	if err != nil {
+		t.Fatalf("error should not be raised: %s", err)
+	}
+
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("marshaled spec should be parsed: %s\n%s", err, string(data))
+	}
+
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("spec does not match after roundtrip. expected: %#v, got: %#v", s, got)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	updated := map[string]interface{}{
+		"Version": "2008-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Sid":       "pull",
+				"Effect":    "Deny",
+				"Principal": map[string]interface{}{"AWS": "arn:aws:iam::109876543210:root"},
+				"Action":    []interface{}{"ecr:BatchGetImage", "ecr:GetDownloadUrlForLayer"},
+			},
+		},
+	}
+
+	s := &Spec{
+		Repositories: []*Repository{
+			&Repository{Name: "unchanged", Policy: testPolicy},
+			&Repository{Name: "new", Policy: testPolicy},
+			&Repository{Name: "updated", Policy: updated},
+			&Repository{Name: "policy-removed"},
+			&Repository{Name: "another-new"},
+		},
+	}
+
+	current := State{
+		"unchanged":      testPolicy,
+		"updated":        testPolicy,
+		"policy-removed": testPolicy,
+		"removed":        nil,
+	}
+
+	got := Plan(s, current)
+
+	expected := []struct {
+		action     string
+		repository string
+		policy     map[string]interface{}
+		diff       []string
+	}{
+		{ActionCreate, "another-new", nil, []string{}},
+		{ActionCreate, "new", testPolicy, nil},
+		{ActionUpdate, "policy-removed", nil, nil},
+		{ActionUpdate, "updated", updated, []string{`- Statement[0].Effect: "Allow"`, `+ Statement[0].Effect: "Deny"`}},
+		{ActionDelete, "removed", nil, []string{}},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("number of changes does not match. expected: %d, got: %d (%v)", len(expected), len(got), got)
+	}
+
+	for i, e := range expected {
+		c := got[i]
+
+		if c.Action != e.action || c.Repository != e.repository {
+			t.Errorf("change[%d] does not match. expected: %s %s, got: %s", i, e.action, e.repository, c)
+			continue
+		}
+
+		if !reflect.DeepEqual(c.Policy, e.policy) {
+			t.Errorf("policy of change[%d] does not match. expected: %#v, got: %#v", i, e.policy, c.Policy)
+		}
+
+		if e.diff != nil && !reflect.DeepEqual(c.Diff, e.diff) {
+			t.Errorf("diff of change[%d] does not match. expected: %q, got: %q", i, e.diff, c.Diff)
+		}
+
+		if e.diff == nil && len(c.Diff) == 0 {
+			t.Errorf("diff of change[%d] should not be empty", i)
+		}
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	testcases := []struct {
+		change   *Change
+		expected string
+	}{
+		{&Change{Action: ActionCreate, Repository: "foo"}, "create foo"},
+		{&Change{Action: ActionUpdate, Repository: "foo", Policy: testPolicy}, "update foo"},
+		{&Change{Action: ActionUpdate, Repository: "foo"}, "update foo (delete policy)"},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.change.String(); got != tc.expected {
+			t.Errorf("string does not match. expected: %q, got: %q", tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tokencache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ecrcli/aws/ecr"
+	"github.com/dtan4/ecrcli/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ExpiryMargin is the duration before ExpiresAt in which cached credentials are no longer used
+	ExpiryMargin = 15 * time.Minute
+
+	// DefaultAccount is the account part of key for the default registry of the caller
+	DefaultAccount = "default"
+)
+
+var (
+	unsafeKeyCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+// Cache stores ECR credentials on disk until shortly before they expire
+type Cache struct {
+	dir string
+}
+
+// New creates new Cache storing files in the given directory
+func New(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+	}
+}
+
+// DefaultDir returns the user cache directory for ecrcli
+// $XDG_CACHE_HOME/ecrcli, ~/Library/Caches/ecrcli on macOS, ~/.cache/ecrcli otherwise
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "ecrcli"), nil
+	}
+
+	home, err := fileutil.HomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(home, "Library", "Caches", "ecrcli"), nil
+	}
+
+	return filepath.Join(home, ".cache", "ecrcli"), nil
+}
+
+// Key returns the cache key of credentials for the given account, region, profile and access key ID
+// Access key ID distinguishes identities sharing the same profile, e.g. different AWS_ACCESS_KEY_ID or assumed roles,
+// because the default registry depends on the caller. Only its hash is included in the key
+func Key(account, region, profile, accessKeyID string) string {
+	if account == "" {
+		account = DefaultAccount
+	}
+
+	if profile == "" {
+		profile = "default"
+	}
+
+	sum := sha256.Sum256([]byte(accessKeyID))
+
+	return strings.Join([]string{account, region, profile, hex.EncodeToString(sum[:])[:16]}, "_")
+}
+
+// Profile returns the AWS profile name in use, from $AWS_PROFILE or $AWS_DEFAULT_PROFILE
+func Profile() string {
+	if p := os.Getenv("AWS_PROFILE"); p != "" {
+		return p
+	}
+
+	return os.Getenv("AWS_DEFAULT_PROFILE")
+}
+
+// Get returns the cached credentials for the given key
+// It returns false if credentials are not cached, unreadable or expire within ExpiryMargin
+func (c *Cache) Get(key string, now time.Time) (*ecr.Credentials, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var creds ecr.Credentials
+
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, false
+	}
+
+	if creds.Password == "" || !now.Add(ExpiryMargin).Before(creds.ExpiresAt) {
+		return nil, false
+	}
+
+	return &creds, true
+}
+
+// Set stores the credentials for the given key with 0600 permission
+func (c *Cache) Set(key string, creds *ecr.Credentials) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode credentials")
+	}
+
+	return fileutil.WriteFileAtomic(c.path(key), data, 0600)
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, unsafeKeyCharsRegexp.ReplaceAllString(key, "_")+".json")
+}